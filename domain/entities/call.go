@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// CallAttempt is logged on the delivery every time the partner tries to call the
+// customer, so failed-attempt disputes can be settled later.
+type CallAttempt struct {
+	SessionID   string    `json:"sessionId,omitempty" bson:"sessionId,omitempty"`
+	PartnerID   string    `json:"partnerId" bson:"partnerId"`
+	Provider    string    `json:"provider" bson:"provider"`
+	Status      string    `json:"status" bson:"status"` // initiated, failed
+	Error       string    `json:"error,omitempty" bson:"error,omitempty"`
+	AttemptedAt time.Time `json:"attemptedAt" bson:"attemptedAt"`
+	ExpiresAt   time.Time `json:"expiresAt,omitempty" bson:"expiresAt,omitempty"`
+}
+
+// CallBridgeRequest asks the provider for a short-lived number that connects both parties
+type CallBridgeRequest struct {
+	DeliveryID  string
+	CallerPhone string
+	CalleePhone string
+	TTL         time.Duration
+}
+
+type CallBridgeSession struct {
+	SessionID   string    `json:"sessionId"`
+	ProxyNumber string    `json:"proxyNumber"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// Requests and Responses
+
+type CallCustomerResponse struct {
+	Success     bool      `json:"success"`
+	Message     string    `json:"message"`
+	ProxyNumber string    `json:"proxyNumber,omitempty"` // dial this number to reach the customer
+	ExpiresAt   time.Time `json:"expiresAt,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
@@ -0,0 +1,60 @@
+package entities
+
+import "time"
+
+// ChatMessage is one message in the per-delivery conversation between the
+// partner, the customer and support
+type ChatMessage struct {
+	MessageID  string           `json:"id" bson:"_id,omitempty"`
+	DeliveryID string           `json:"deliveryId" bson:"deliveryId"`
+	SenderRole string           `json:"senderRole" bson:"senderRole"` // partner, customer, support
+	SenderID   string           `json:"senderId,omitempty" bson:"senderId,omitempty"`
+	SenderName string           `json:"senderName" bson:"senderName"`
+	Body       string           `json:"body" bson:"body"`
+	TemplateID string           `json:"templateId,omitempty" bson:"templateId,omitempty"`
+	ReadBy     []MessageReceipt `json:"readBy" bson:"readBy"`
+	CreatedAt  time.Time        `json:"createdAt" bson:"createdAt"`
+}
+
+type MessageReceipt struct {
+	Role   string    `json:"role" bson:"role"`
+	ReadAt time.Time `json:"readAt" bson:"readAt"`
+}
+
+// QuickReply is a canned message a participant can send with one tap
+type QuickReply struct {
+	TemplateID string `json:"id"`
+	Body       string `json:"body"`
+}
+
+// Requests and Responses
+
+type GetMessagesRequest struct {
+	After string `json:"after" form:"after"`                      // ID of the last message the client has
+	Wait  int    `json:"wait" form:"wait" binding:"gte=0,lte=30"` // seconds to wait for new messages
+}
+
+type SendMessageRequest struct {
+	Body       string `json:"body" binding:"max=1000"`
+	TemplateID string `json:"templateId"`
+}
+
+type GetMessagesResponse struct {
+	Success     bool          `json:"success"`
+	Messages    []ChatMessage `json:"messages"`
+	UnreadCount int           `json:"unreadCount"`
+	IsReadOnly  bool          `json:"isReadOnly"`
+	Error       string        `json:"error,omitempty"`
+}
+
+type SendMessageResponse struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message,omitempty"`
+	Chat    *ChatMessage `json:"chat,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+type GetQuickRepliesResponse struct {
+	Success      bool         `json:"success"`
+	QuickReplies []QuickReply `json:"quickReplies"`
+}
@@ -9,20 +9,29 @@ type Delivery struct {
 	PartnerID        string    `json:"partnerId" bson:"partnerId"`
 	CustomerID       string    `json:"customerId" bson:"customerId"`
 	CustomerName     string    `json:"customerName" bson:"customerName"`
-	CustomerPhone    string    `json:"customerPhone" bson:"customerPhone"`
+	CustomerPhone    string    `json:"customerPhone" bson:"customerPhone"` // masked in partner-facing responses
 	WarehouseID      string    `json:"warehouseId" bson:"warehouseId"`
-	Status           string    `json:"status" bson:"status"` // pending, picked_up, in_transit, delivered, cancelled
+	Status           string    `json:"status" bson:"status"` // pending, assigned, picked_up, in_transit, delivered, cancelled
 	PickupAddress    string    `json:"pickupAddress" bson:"pickupAddress"`
 	DeliveryAddress  string    `json:"deliveryAddress" bson:"deliveryAddress"`
 	PickupLatitude   float64   `json:"pickupLatitude" bson:"pickupLatitude"`
 	PickupLongitude  float64   `json:"pickupLongitude" bson:"pickupLongitude"`
 	DeliveryLatitude float64   `json:"deliveryLatitude" bson:"deliveryLatitude"`
 	DeliveryLongitude float64  `json:"deliveryLongitude" bson:"deliveryLongitude"`
+	// Same coordinates as GeoJSON points for the 2dsphere indexes
+	PickupLocation   *GeoPoint `json:"-" bson:"pickupLocation,omitempty"`
+	DeliveryLocation *GeoPoint `json:"-" bson:"deliveryLocation,omitempty"`
 	Distance         float64   `json:"distance" bson:"distance"` // in km
+	Duration         int       `json:"duration,omitempty" bson:"duration,omitempty"` // pickup to drop travel time, in seconds
+	RouteSource      string    `json:"routeSource,omitempty" bson:"routeSource,omitempty"` // route provider that computed distance and duration
 	OrderAmount      int       `json:"orderAmount" bson:"orderAmount"`
 	DeliveryFee      int       `json:"deliveryFee" bson:"deliveryFee"`
 	ItemsCount       int       `json:"itemsCount" bson:"itemsCount"`
 	Items            []OrderItem `json:"items" bson:"items"`
+	// Load derived from Items, checked against the partner's vehicle capacity
+	WeightKg         float64   `json:"weightKg,omitempty" bson:"weightKg,omitempty"`
+	VolumeLitres     float64   `json:"volumeLitres,omitempty" bson:"volumeLitres,omitempty"`
+	SizeClass        string    `json:"sizeClass,omitempty" bson:"sizeClass,omitempty"` // small, medium, large, xl
 	AssignedAt       time.Time `json:"assignedAt" bson:"assignedAt"`
 	PickedUpAt       *time.Time `json:"pickedUpAt,omitempty" bson:"pickedUpAt,omitempty"`
 	InTransitAt      *time.Time `json:"inTransitAt,omitempty" bson:"inTransitAt,omitempty"`
@@ -33,6 +42,39 @@ type Delivery struct {
 	PaymentMethod    string    `json:"paymentMethod" bson:"paymentMethod"` // cod, online
 	Notes            string    `json:"notes" bson:"notes"`
 	CancellationReason string  `json:"cancellationReason,omitempty" bson:"cancellationReason,omitempty"`
+	// Pickup handover
+	PickupVerification *PickupVerification `json:"pickupVerification,omitempty" bson:"pickupVerification,omitempty"`
+	// SLA and ETA
+	SLADeadline       *time.Time `json:"slaDeadline,omitempty" bson:"slaDeadline,omitempty"`
+	SLAStatus         string     `json:"slaStatus,omitempty" bson:"slaStatus,omitempty"` // on_track, at_risk, breached, met
+	SLABreachedAt     *time.Time `json:"slaBreachedAt,omitempty" bson:"slaBreachedAt,omitempty"`
+	ETA               *time.Time `json:"eta,omitempty" bson:"eta,omitempty"`
+	ETAUpdatedAt      *time.Time `json:"etaUpdatedAt,omitempty" bson:"etaUpdatedAt,omitempty"`
+	RemainingDistance float64    `json:"remainingDistance,omitempty" bson:"remainingDistance,omitempty"` // in km
+	// Scheduled delivery window; both unset means deliver now
+	WindowStart *time.Time `json:"windowStart,omitempty" bson:"windowStart,omitempty"`
+	WindowEnd   *time.Time `json:"windowEnd,omitempty" bson:"windowEnd,omitempty"`
+	// Masked calls to the customer
+	CallAttempts []CallAttempt `json:"callAttempts,omitempty" bson:"callAttempts,omitempty"`
+	// Public customer tracking link
+	TrackingToken     string     `json:"-" bson:"trackingToken,omitempty"`
+	TrackingExpiresAt *time.Time `json:"-" bson:"trackingExpiresAt,omitempty"`
+	// Who held the order when, including releases and reassignments
+	Timeline []TimelineEntry `json:"timeline,omitempty" bson:"timeline,omitempty"`
+	// Location integrity flags raised while the partner held the order and
+	// not yet reviewed
+	OpenLocationFlags int `json:"openLocationFlags,omitempty" bson:"openLocationFlags,omitempty"`
+}
+
+// TimelineEntry records the order changing hands, or getting stuck with one partner
+type TimelineEntry struct {
+	Type              string    `json:"type" bson:"type"`     // assigned, released, stalled
+	Status            string    `json:"status" bson:"status"` // delivery status at the time
+	PartnerID         string    `json:"partnerId,omitempty" bson:"partnerId,omitempty"`
+	PreviousPartnerID string    `json:"previousPartnerId,omitempty" bson:"previousPartnerId,omitempty"`
+	Actor             string    `json:"actor,omitempty" bson:"actor,omitempty"` // watchdog, or the ops actor
+	Reason            string    `json:"reason,omitempty" bson:"reason,omitempty"`
+	At                time.Time `json:"at" bson:"at"`
 }
 
 type OrderItem struct {
@@ -41,6 +83,43 @@ type OrderItem struct {
 	Quantity    int    `json:"quantity" bson:"quantity"`
 	Price       int    `json:"price" bson:"price"`
 	ImageURL    string `json:"imageUrl" bson:"imageUrl"`
+	// Per unit; items without them count with a default
+	WeightKg     float64 `json:"weightKg,omitempty" bson:"weightKg,omitempty"`
+	VolumeLitres float64 `json:"volumeLitres,omitempty" bson:"volumeLitres,omitempty"`
+}
+
+// PickupVerification tracks the handover scans made by the partner at the warehouse
+type PickupVerification struct {
+	ScannedOrderCode string           `json:"scannedOrderCode" bson:"scannedOrderCode"`
+	ScannedItemCodes []string         `json:"scannedItemCodes" bson:"scannedItemCodes"`
+	IsMatched        bool             `json:"isMatched" bson:"isMatched"`
+	ScanAttempts     int              `json:"scanAttempts" bson:"scanAttempts"`
+	LastScannedAt    time.Time        `json:"lastScannedAt" bson:"lastScannedAt"`
+	Mismatches       []PickupMismatch `json:"mismatches" bson:"mismatches"` // every mismatch seen across attempts
+	ConfirmedBy      string           `json:"confirmedBy,omitempty" bson:"confirmedBy,omitempty"`
+	ConfirmedAt      *time.Time       `json:"confirmedAt,omitempty" bson:"confirmedAt,omitempty"`
+	ConfirmationNote string           `json:"confirmationNote,omitempty" bson:"confirmationNote,omitempty"`
+}
+
+type PickupMismatch struct {
+	Type      string    `json:"type" bson:"type"` // order_code, unknown_item, missing_item, quantity
+	Code      string    `json:"code" bson:"code"`
+	Expected  int       `json:"expected" bson:"expected"`
+	Scanned   int       `json:"scanned" bson:"scanned"`
+	Attempt   int       `json:"attempt" bson:"attempt"`
+	ScannedAt time.Time `json:"scannedAt" bson:"scannedAt"`
+}
+
+// DeliverySLAUpdate holds the ETA/SLA fields written by the SLA monitor.
+// Nil fields are left untouched.
+type DeliverySLAUpdate struct {
+	SLADeadline       *time.Time
+	SLAStatus         string
+	SLABreachedAt     *time.Time
+	ETA               *time.Time
+	RemainingDistance float64
+	// Set when the pickup to drop route was computed during the check
+	Route *Route
 }
 
 // Requests and Responses
@@ -60,9 +139,27 @@ type DeliveryListItem struct {
 	DeliveryFee int       `json:"deliveryFee"`
 	ItemsCount  int       `json:"itemsCount"`
 	Distance    float64   `json:"distance"`
+	PickupDistance float64 `json:"pickupDistance,omitempty"` // from the partner, nearby orders only
+	ETA         *time.Time `json:"eta,omitempty"`
+	SLAStatus   string    `json:"slaStatus,omitempty"`
+	WindowStart *time.Time `json:"windowStart,omitempty"`
+	WindowEnd   *time.Time `json:"windowEnd,omitempty"`
 	CreatedAt   time.Time `json:"createdAt"`
 }
 
+type GetNearbyOrdersRequest struct {
+	RadiusKm float64 `json:"radiusKm" form:"radiusKm" binding:"gt=0,lte=25"`
+	Limit    int     `json:"limit" form:"limit" binding:"gte=1,lte=50"`
+}
+
+type GetNearbyOrdersResponse struct {
+	Success  bool               `json:"success"`
+	Message  string             `json:"message,omitempty"`
+	RadiusKm float64            `json:"radiusKm"`
+	Orders   []DeliveryListItem `json:"orders"`
+	Count    int                `json:"count"`
+}
+
 type GetOrderHistoryRequest struct {
 	Limit  int `json:"limit" form:"limit" binding:"gte=1"`
 	Offset int `json:"offset" form:"offset" binding:"gte=0"`
@@ -78,6 +175,36 @@ type GetOrderHistoryResponse struct {
 	HasPrevious bool               `json:"hasPrevious"`
 }
 
+// CreateDeliveryRequest registers an order for delivery. Distance and
+// duration are computed from the coordinates.
+type CreateDeliveryRequest struct {
+	OrderID           string      `json:"orderId" binding:"required"`
+	CustomerID        string      `json:"customerId" binding:"required"`
+	CustomerName      string      `json:"customerName" binding:"required"`
+	CustomerPhone     string      `json:"customerPhone" binding:"required"`
+	WarehouseID       string      `json:"warehouseId" binding:"required"`
+	PickupAddress     string      `json:"pickupAddress" binding:"required"`
+	DeliveryAddress   string      `json:"deliveryAddress" binding:"required"`
+	PickupLatitude    float64     `json:"pickupLatitude" binding:"required,gte=-90,lte=90"`
+	PickupLongitude   float64     `json:"pickupLongitude" binding:"required,gte=-180,lte=180"`
+	DeliveryLatitude  float64     `json:"deliveryLatitude" binding:"required,gte=-90,lte=90"`
+	DeliveryLongitude float64     `json:"deliveryLongitude" binding:"required,gte=-180,lte=180"`
+	OrderAmount       int         `json:"orderAmount" binding:"gte=0"`
+	DeliveryFee       int         `json:"deliveryFee" binding:"gte=0"`
+	Items             []OrderItem `json:"items"`
+	PaymentMethod     string      `json:"paymentMethod" binding:"required,oneof=cod online"`
+	Notes             string      `json:"notes"`
+	WindowStart       *time.Time  `json:"windowStart"`
+	WindowEnd         *time.Time  `json:"windowEnd"`
+}
+
+type CreateDeliveryResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message,omitempty"`
+	Order   *Delivery `json:"order,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
 type GetOrderDetailsResponse struct {
 	Success bool      `json:"success"`
 	Order   *Delivery `json:"order"`
@@ -96,3 +223,69 @@ type CompleteDeliveryRequest struct {
 	Notes     string  `json:"notes"`
 }
 
+
+type PickupScanRequest struct {
+	OrderCode string   `json:"orderCode" binding:"required"`
+	ItemCodes []string `json:"itemCodes"` // one entry per scanned unit, optional
+}
+
+type PickupScanResponse struct {
+	Success    bool             `json:"success"`
+	Message    string           `json:"message"`
+	IsMatched  bool             `json:"isMatched"`
+	Mismatches []PickupMismatch `json:"mismatches"`
+	Error      string           `json:"error,omitempty"`
+}
+
+type ConfirmPickupRequest struct {
+	ConfirmedBy string `json:"confirmedBy"`
+	Override    bool   `json:"override"` // confirm even though the last scan had mismatches
+	Note        string `json:"note"`
+}
+
+type GetSLAAlertsRequest struct {
+	Status      string `json:"status" form:"status"` // comma separated: at_risk, breached
+	WarehouseID string `json:"warehouseId" form:"warehouseId"`
+}
+
+type SLAAlertItem struct {
+	ID          string     `json:"id"`
+	OrderID     string     `json:"orderId"`
+	PartnerID   string     `json:"partnerId"`
+	WarehouseID string     `json:"warehouseId"`
+	Status      string     `json:"status"`
+	SLAStatus   string     `json:"slaStatus"`
+	SLADeadline *time.Time `json:"slaDeadline"`
+	ETA         *time.Time `json:"eta,omitempty"`
+	MinutesLate int        `json:"minutesLate"` // projected or actual minutes past the deadline
+}
+
+type GetSLAAlertsResponse struct {
+	Success    bool           `json:"success"`
+	Deliveries []SLAAlertItem `json:"deliveries"`
+	Count      int            `json:"count"`
+}
+
+type GetSlotAdherenceRequest struct {
+	Period      string `json:"period" form:"period"`   // today, week, month
+	GroupBy     string `json:"groupBy" form:"groupBy"` // partner, warehouse
+	WarehouseID string `json:"warehouseId" form:"warehouseId"`
+	PartnerID   string `json:"partnerId" form:"partnerId"`
+}
+
+// SlotAdherence summarises how scheduled deliveries landed against their windows
+type SlotAdherence struct {
+	GroupID        string  `json:"groupId" bson:"_id"` // partner or warehouse ID
+	Total          int     `json:"total" bson:"total"`
+	OnTime         int     `json:"onTime" bson:"onTime"`
+	Late           int     `json:"late" bson:"late"`
+	AvgMinutesLate float64 `json:"avgMinutesLate" bson:"avgMinutesLate"` // across late deliveries only
+	AdherenceRate  float64 `json:"adherenceRate" bson:"-"`               // onTime / total
+}
+
+type GetSlotAdherenceResponse struct {
+	Success bool            `json:"success"`
+	Period  string          `json:"period"`
+	GroupBy string          `json:"groupBy"`
+	Groups  []SlotAdherence `json:"groups"`
+}
@@ -32,8 +32,30 @@ type DeliveryPartner struct {
 	CurrentLatitude  float64 `json:"currentLatitude" bson:"currentLatitude"`
 	CurrentLongitude float64 `json:"currentLongitude" bson:"currentLongitude"`
 	LastLocationAt   time.Time `json:"lastLocationAt" bson:"lastLocationAt"`
+	// Same position as a GeoJSON point for the 2dsphere index
+	Location *GeoPoint `json:"-" bson:"location,omitempty"`
+	// Last sign of life from the app
+	LastHeartbeatAt time.Time `json:"lastHeartbeatAt,omitempty" bson:"lastHeartbeatAt,omitempty"`
+	// online, on_break, stale or offline; kept by the presence sweeper. Busy
+	// is worked out from active orders when presence is shown.
+	Presence string `json:"presence,omitempty" bson:"presence,omitempty"`
+	// Location integrity flags waiting for ops review
+	OpenLocationFlags int `json:"openLocationFlags,omitempty" bson:"openLocationFlags,omitempty"`
+	// Zone the partner normally works in; dispatch offers them that zone's orders
+	HomeZoneID string `json:"homeZoneId,omitempty" bson:"homeZoneId,omitempty"`
+	// Written with every capacity-checked assignment so concurrent ones conflict
+	LastAssignedAt *time.Time `json:"lastAssignedAt,omitempty" bson:"lastAssignedAt,omitempty"`
 }
 
+// Presence states
+const (
+	PresenceOnline  = "online"
+	PresenceBusy    = "busy"
+	PresenceOnBreak = "on_break"
+	PresenceStale   = "stale"
+	PresenceOffline = "offline"
+)
+
 // Login Request/Response
 type DeliveryPartnerLoginRequest struct {
 	PhoneNumber string `json:"phoneNumber" binding:"required,min=10"`
@@ -91,8 +113,14 @@ type UpdateProfileRequest struct {
 
 // Location Update
 type UpdateLocationRequest struct {
-	Latitude  float64 `json:"latitude" binding:"required"`
-	Longitude float64 `json:"longitude" binding:"required"`
+	Latitude   float64    `json:"latitude" binding:"required"`
+	Longitude  float64    `json:"longitude" binding:"required"`
+	Accuracy   *float64   `json:"accuracy" binding:"omitempty,gte=0"`
+	Speed      *float64   `json:"speed" binding:"omitempty,gte=0"`
+	Heading    *float64   `json:"heading" binding:"omitempty,gte=0,lt=360"`
+	Battery    *int       `json:"battery" binding:"omitempty,gte=0,lte=100"`
+	IsMock     bool       `json:"isMock"` // the OS reported a mock location
+	RecordedAt *time.Time `json:"recordedAt"` // when the fix was taken; defaults to now
 }
 
 // Availability Toggle
@@ -100,6 +128,25 @@ type ToggleAvailabilityRequest struct {
 	IsAvailable bool `json:"isAvailable"`
 }
 
+// Break Toggle
+type SetBreakRequest struct {
+	OnBreak bool `json:"onBreak"`
+}
+
+type PresenceResponse struct {
+	Success  bool   `json:"success"`
+	Message  string `json:"message,omitempty"`
+	Presence string `json:"presence,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// PresenceSweep counts the partners a sweep moved to each state
+type PresenceSweep struct {
+	Stale   int64 `json:"stale"`
+	Online  int64 `json:"online"`
+	Offline int64 `json:"offline"`
+}
+
 type ResponseMessage struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
@@ -0,0 +1,49 @@
+package entities
+
+// DispatchCandidate is a partner scored for a delivery. Score is 0-1, higher is better.
+type DispatchCandidate struct {
+	PartnerID    string  `json:"partnerId"`
+	Name         string  `json:"name"`
+	VehicleType  string  `json:"vehicleType"`
+	DistanceKm   float64 `json:"distanceKm"` // to the pickup
+	ActiveOrders int     `json:"activeOrders"`
+	Rating       float64 `json:"rating"`
+	Score        float64 `json:"score"`
+}
+
+// DispatchDecision records what the dispatcher did with one pending delivery
+type DispatchDecision struct {
+	DeliveryID string  `json:"deliveryId"`
+	OrderID    string  `json:"orderId"`
+	PartnerID  string  `json:"partnerId,omitempty"`
+	Score      float64 `json:"score,omitempty"`
+	Action     string  `json:"action"` // assigned, offered, skipped, failed
+	Reason     string  `json:"reason,omitempty"`
+}
+
+// CapacityCheck is the order's load and the partner's vehicle limits, checked
+// against the orders the partner already carries when the order is assigned
+type CapacityCheck struct {
+	WeightKg        float64
+	VolumeLitres    float64
+	MaxWeightKg     float64
+	MaxVolumeLitres float64
+}
+
+// Requests and Responses
+
+type DispatchRunResponse struct {
+	Success    bool               `json:"success"`
+	Strategy   string             `json:"strategy"`
+	Considered int                `json:"considered"`
+	Dispatched int                `json:"dispatched"`
+	Decisions  []DispatchDecision `json:"decisions"`
+	Error      string             `json:"error,omitempty"`
+}
+
+type GetDispatchCandidatesResponse struct {
+	Success    bool                `json:"success"`
+	DeliveryID string              `json:"deliveryId"`
+	Candidates []DispatchCandidate `json:"candidates"`
+	Error      string              `json:"error,omitempty"`
+}
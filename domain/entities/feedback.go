@@ -0,0 +1,57 @@
+package entities
+
+import "time"
+
+// Feedback is the customer's rating of a completed delivery. There is at most
+// one per delivery.
+type Feedback struct {
+	FeedbackID     string     `json:"id" bson:"_id,omitempty"`
+	DeliveryID     string     `json:"deliveryId" bson:"deliveryId"`
+	OrderID        string     `json:"orderId" bson:"orderId"`
+	PartnerID      string     `json:"partnerId" bson:"partnerId"`
+	Rating         int        `json:"rating" bson:"rating"`
+	Tags           []string   `json:"tags,omitempty" bson:"tags,omitempty"`
+	Comment        string     `json:"comment,omitempty" bson:"comment,omitempty"`
+	Source         string     `json:"source" bson:"source"` // customer, order_service
+	IsExcluded     bool       `json:"isExcluded" bson:"isExcluded"`
+	ExcludedBy     string     `json:"excludedBy,omitempty" bson:"excludedBy,omitempty"`
+	ExcludedReason string     `json:"excludedReason,omitempty" bson:"excludedReason,omitempty"`
+	ExcludedAt     *time.Time `json:"excludedAt,omitempty" bson:"excludedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Requests and Responses
+
+type SubmitFeedbackRequest struct {
+	Rating  int      `json:"rating" binding:"required,min=1,max=5"`
+	Tags    []string `json:"tags" binding:"max=10,dive,max=40"`
+	Comment string   `json:"comment" binding:"max=1000"`
+}
+
+type SubmitFeedbackResponse struct {
+	Success  bool      `json:"success"`
+	Message  string    `json:"message,omitempty"`
+	Feedback *Feedback `json:"feedback,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type ExcludeFeedbackRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type GetPartnerFeedbackRequest struct {
+	Limit  int `json:"limit" form:"limit" binding:"gte=1"`
+	Offset int `json:"offset" form:"offset" binding:"gte=0"`
+}
+
+type GetPartnerFeedbackResponse struct {
+	Success     bool       `json:"success"`
+	Rating      float64    `json:"rating"`
+	Feedback    []Feedback `json:"feedback"`
+	Total       int        `json:"total"`
+	Limit       int        `json:"limit"`
+	Offset      int        `json:"offset"`
+	HasNext     bool       `json:"hasNext"`
+	HasPrevious bool       `json:"hasPrevious"`
+}
@@ -0,0 +1,90 @@
+package entities
+
+import "time"
+
+// FleetPartner is a partner as shown on the ops fleet console
+type FleetPartner struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	PhoneNumber     string     `json:"phoneNumber"`
+	VehicleType     string     `json:"vehicleType"`
+	IsAvailable     bool       `json:"isAvailable"`
+	Presence        string     `json:"presence"`
+	HomeZoneID      string     `json:"homeZoneId,omitempty"`
+	Latitude        float64    `json:"latitude"`
+	Longitude       float64    `json:"longitude"`
+	LastLocationAt  *time.Time `json:"lastLocationAt,omitempty"`
+	LastHeartbeatAt *time.Time `json:"lastHeartbeatAt,omitempty"`
+	ActiveOrders    int        `json:"activeOrders"`
+	Rating          float64    `json:"rating"`
+}
+
+// FleetOrder is an order as shown on the ops fleet console
+type FleetOrder struct {
+	ID              string     `json:"id"`
+	OrderID         string     `json:"orderId"`
+	Status          string     `json:"status"`
+	PartnerID       string     `json:"partnerId,omitempty"`
+	WarehouseID     string     `json:"warehouseId"`
+	PickupAddress   string     `json:"pickupAddress"`
+	DeliveryAddress string     `json:"deliveryAddress"`
+	SizeClass       string     `json:"sizeClass,omitempty"`
+	SLAStatus       string     `json:"slaStatus,omitempty"`
+	SLADeadline     *time.Time `json:"slaDeadline,omitempty"`
+	ETA             *time.Time `json:"eta,omitempty"`
+	MinutesLate     int        `json:"minutesLate"`
+	WindowStart     *time.Time `json:"windowStart,omitempty"`
+	WindowEnd       *time.Time `json:"windowEnd,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+// Requests and Responses
+
+type GetFleetPartnersRequest struct {
+	WarehouseID string `json:"warehouseId" form:"warehouseId"`
+	ZoneID      string `json:"zoneId" form:"zoneId"`
+	Available   string `json:"available" form:"available" binding:"omitempty,oneof=true false"`
+	Limit       int    `json:"limit" form:"limit" binding:"gte=1,lte=200"`
+	Offset      int    `json:"offset" form:"offset" binding:"gte=0"`
+}
+
+type GetFleetPartnersResponse struct {
+	Success     bool           `json:"success"`
+	Partners    []FleetPartner `json:"partners"`
+	Total       int            `json:"total"`
+	Limit       int            `json:"limit"`
+	Offset      int            `json:"offset"`
+	HasNext     bool           `json:"hasNext"`
+	HasPrevious bool           `json:"hasPrevious"`
+	Message     string         `json:"message,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+type GetFleetOrdersRequest struct {
+	WarehouseID string `json:"warehouseId" form:"warehouseId"`
+	ZoneID      string `json:"zoneId" form:"zoneId"`
+	Limit       int    `json:"limit" form:"limit" binding:"gte=1,lte=200"`
+	Offset      int    `json:"offset" form:"offset" binding:"gte=0"`
+}
+
+type GetFleetOrdersResponse struct {
+	Success     bool         `json:"success"`
+	Orders      []FleetOrder `json:"orders"`
+	Total       int          `json:"total"`
+	Limit       int          `json:"limit"`
+	Offset      int          `json:"offset"`
+	HasNext     bool         `json:"hasNext"`
+	HasPrevious bool         `json:"hasPrevious"`
+	Message     string       `json:"message,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+type ManualAssignRequest struct {
+	PartnerID string `json:"partnerId" binding:"required"`
+	Reason    string `json:"reason" binding:"required,max=500"`
+	Force     bool   `json:"force"` // assign even if the order doesn't fit the partner's vehicle
+}
+
+type ManualUnassignRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,31 @@
+package entities
+
+// GeoPoint is a GeoJSON point. Coordinates are [longitude, latitude], the
+// order MongoDB's 2dsphere index expects.
+type GeoPoint struct {
+	Type        string    `json:"type" bson:"type"`
+	Coordinates []float64 `json:"coordinates" bson:"coordinates"`
+}
+
+func NewGeoPoint(latitude, longitude float64) *GeoPoint {
+	return &GeoPoint{
+		Type:        "Point",
+		Coordinates: []float64{longitude, latitude},
+	}
+}
+
+// Latitude and Longitude read the point back in the order used elsewhere in the API
+func (p *GeoPoint) Latitude() float64 {
+	return p.Coordinates[1]
+}
+
+func (p *GeoPoint) Longitude() float64 {
+	return p.Coordinates[0]
+}
+
+// GeoPolygon is a GeoJSON polygon: an outer ring followed by optional holes.
+// Each ring is a closed list of [longitude, latitude] positions.
+type GeoPolygon struct {
+	Type        string        `json:"type" bson:"type"`
+	Coordinates [][][]float64 `json:"coordinates" bson:"coordinates"`
+}
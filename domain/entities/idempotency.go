@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// IdempotencyRecord stores the outcome of a request made with an Idempotency-Key
+// so that retries replay the original response instead of re-running it.
+type IdempotencyRecord struct {
+	Key            string    `json:"key" bson:"_id"` // scoped as <partnerId>:<Idempotency-Key>
+	PartnerID      string    `json:"partnerId" bson:"partnerId"`
+	Method         string    `json:"method" bson:"method"`
+	Path           string    `json:"path" bson:"path"`
+	Fingerprint    string    `json:"fingerprint" bson:"fingerprint"`
+	Status         string    `json:"status" bson:"status"` // in_progress, completed
+	ResponseStatus int       `json:"responseStatus" bson:"responseStatus"`
+	ResponseBody   []byte    `json:"-" bson:"responseBody"`
+	ContentType    string    `json:"contentType" bson:"contentType"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+	ExpiresAt      time.Time `json:"expiresAt" bson:"expiresAt"`
+}
@@ -0,0 +1,75 @@
+package entities
+
+import "time"
+
+// Incident is a structured problem report a partner raises on a delivery
+type Incident struct {
+	IncidentID     string     `json:"id" bson:"_id,omitempty"`
+	DeliveryID     string     `json:"deliveryId" bson:"deliveryId"`
+	OrderID        string     `json:"orderId" bson:"orderId"`
+	PartnerID      string     `json:"partnerId" bson:"partnerId"`
+	Category       string     `json:"category" bson:"category"`
+	Severity       string     `json:"severity" bson:"severity"` // low, medium, high, critical
+	Description    string     `json:"description,omitempty" bson:"description,omitempty"`
+	PhotoURLs      []string   `json:"photoUrls,omitempty" bson:"photoUrls,omitempty"`
+	Latitude       *float64   `json:"latitude,omitempty" bson:"latitude,omitempty"`
+	Longitude      *float64   `json:"longitude,omitempty" bson:"longitude,omitempty"`
+	DeliveryStatus string     `json:"deliveryStatus" bson:"deliveryStatus"` // order status when reported
+	Status         string     `json:"status" bson:"status"`                 // open, acknowledged, resolved
+	AcknowledgedBy string     `json:"acknowledgedBy,omitempty" bson:"acknowledgedBy,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty" bson:"acknowledgedAt,omitempty"`
+	ResolvedBy     string     `json:"resolvedBy,omitempty" bson:"resolvedBy,omitempty"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty" bson:"resolvedAt,omitempty"`
+	Resolution     string     `json:"resolution,omitempty" bson:"resolution,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Requests and Responses
+
+type ReportIncidentRequest struct {
+	Category    string   `json:"category" binding:"required,oneof=damaged_at_pickup wrong_address customer_unavailable customer_abusive unsafe_location vehicle_breakdown accident other"`
+	Severity    string   `json:"severity" binding:"required,oneof=low medium high critical"`
+	Description string   `json:"description" binding:"max=2000"`
+	PhotoURLs   []string `json:"photoUrls" binding:"max=5,dive,url"`
+	Latitude    *float64 `json:"latitude" binding:"omitempty,latitude"`
+	Longitude   *float64 `json:"longitude" binding:"omitempty,longitude"`
+}
+
+type IncidentResponse struct {
+	Success  bool      `json:"success"`
+	Message  string    `json:"message,omitempty"`
+	Incident *Incident `json:"incident,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type GetDeliveryIncidentsResponse struct {
+	Success   bool       `json:"success"`
+	Incidents []Incident `json:"incidents"`
+	Count     int        `json:"count"`
+	Error     string     `json:"error,omitempty"`
+}
+
+type GetIncidentsRequest struct {
+	Status     string `json:"status" form:"status"`     // comma separated
+	Severity   string `json:"severity" form:"severity"` // comma separated
+	Category   string `json:"category" form:"category"`
+	PartnerID  string `json:"partnerId" form:"partnerId"`
+	DeliveryID string `json:"deliveryId" form:"deliveryId"`
+	Limit      int    `json:"limit" form:"limit" binding:"gte=1"`
+	Offset     int    `json:"offset" form:"offset" binding:"gte=0"`
+}
+
+type GetIncidentsResponse struct {
+	Success     bool       `json:"success"`
+	Incidents   []Incident `json:"incidents"`
+	Total       int        `json:"total"`
+	Limit       int        `json:"limit"`
+	Offset      int        `json:"offset"`
+	HasNext     bool       `json:"hasNext"`
+	HasPrevious bool       `json:"hasPrevious"`
+}
+
+type ResolveIncidentRequest struct {
+	Resolution string `json:"resolution" binding:"required"`
+}
@@ -0,0 +1,74 @@
+package entities
+
+import "time"
+
+// LocationPing is one GPS fix reported by a partner's app. Pings are kept in
+// a time-series collection for a retention period, so routes can be replayed.
+type LocationPing struct {
+	PartnerID  string    `json:"partnerId" bson:"partnerId"`
+	Latitude   float64   `json:"latitude" bson:"latitude"`
+	Longitude  float64   `json:"longitude" bson:"longitude"`
+	Accuracy   *float64  `json:"accuracy,omitempty" bson:"accuracy,omitempty"` // metres
+	Speed      *float64  `json:"speed,omitempty" bson:"speed,omitempty"`       // metres per second
+	Heading    *float64  `json:"heading,omitempty" bson:"heading,omitempty"`   // degrees from north
+	Battery    *int      `json:"battery,omitempty" bson:"battery,omitempty"`   // percent
+	IsMock     bool      `json:"isMock,omitempty" bson:"isMock,omitempty"`     // the OS reported a mock location
+	Flags      []string  `json:"flags,omitempty" bson:"flags,omitempty"`       // integrity checks it failed
+	RecordedAt time.Time `json:"recordedAt" bson:"recordedAt"`                 // device time
+	ReceivedAt time.Time `json:"receivedAt" bson:"receivedAt"`
+}
+
+// Requests and Responses
+
+// LocationPoint is one buffered fix in a batch upload
+type LocationPoint struct {
+	Latitude   float64   `json:"latitude" binding:"required,latitude"`
+	Longitude  float64   `json:"longitude" binding:"required,longitude"`
+	Accuracy   *float64  `json:"accuracy" binding:"omitempty,gte=0"`
+	Speed      *float64  `json:"speed" binding:"omitempty,gte=0"`
+	Heading    *float64  `json:"heading" binding:"omitempty,gte=0,lt=360"`
+	Battery    *int      `json:"battery" binding:"omitempty,gte=0,lte=100"`
+	IsMock     bool      `json:"isMock"`
+	RecordedAt time.Time `json:"recordedAt" binding:"required"`
+}
+
+type LocationBatchRequest struct {
+	Points []LocationPoint `json:"points" binding:"required,min=1,max=500,dive"`
+}
+
+type LocationBatchResponse struct {
+	Success         bool   `json:"success"`
+	Message         string `json:"message,omitempty"`
+	Accepted        int    `json:"accepted"`
+	Duplicates      int    `json:"duplicates"`
+	Expired         int    `json:"expired"`
+	LocationUpdated bool   `json:"locationUpdated"`
+	Error           string `json:"error,omitempty"`
+}
+
+type GetPartnerLocationsRequest struct {
+	From  time.Time `json:"from" form:"from" binding:"required"`
+	To    time.Time `json:"to" form:"to"` // defaults to now
+	Limit int       `json:"limit" form:"limit" binding:"gte=1,lte=5000"`
+}
+
+type GetPartnerLocationsResponse struct {
+	Success   bool           `json:"success"`
+	PartnerID string         `json:"partnerId"`
+	Points    []LocationPing `json:"points"`
+	Count     int            `json:"count"`
+	Error     string         `json:"error,omitempty"`
+}
+
+type GetDeliveryTrailResponse struct {
+	Success    bool           `json:"success"`
+	Message    string         `json:"message,omitempty"`
+	DeliveryID string         `json:"deliveryId"`
+	PartnerID  string         `json:"partnerId,omitempty"`
+	From       *time.Time     `json:"from,omitempty"`
+	To         *time.Time     `json:"to,omitempty"`
+	Points     []LocationPing `json:"points"`
+	Count      int            `json:"count"`
+	DistanceKm float64        `json:"distanceKm"`
+	Error      string         `json:"error,omitempty"`
+}
@@ -0,0 +1,58 @@
+package entities
+
+import "time"
+
+// LocationFlag is a location fix that failed an integrity check, queued for
+// ops to review. While open it counts against the partner and the orders they
+// held at the time.
+type LocationFlag struct {
+	FlagID      string     `json:"id" bson:"_id,omitempty"`
+	PartnerID   string     `json:"partnerId" bson:"partnerId"`
+	DeliveryIDs []string   `json:"deliveryIds" bson:"deliveryIds"`
+	Type        string     `json:"type" bson:"type"` // impossible_speed, teleport, mock_location, zero_accuracy
+	Details     string     `json:"details" bson:"details"`
+	Latitude    float64    `json:"latitude" bson:"latitude"`
+	Longitude   float64    `json:"longitude" bson:"longitude"`
+	DistanceKm  *float64   `json:"distanceKm,omitempty" bson:"distanceKm,omitempty"` // from the previous fix
+	SpeedKmh    *float64   `json:"speedKmh,omitempty" bson:"speedKmh,omitempty"`     // implied by the previous fix
+	RecordedAt  time.Time  `json:"recordedAt" bson:"recordedAt"`
+	Status      string     `json:"status" bson:"status"` // open, dismissed, confirmed
+	ReviewedBy  string     `json:"reviewedBy,omitempty" bson:"reviewedBy,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewedAt,omitempty" bson:"reviewedAt,omitempty"`
+	ReviewNote  string     `json:"reviewNote,omitempty" bson:"reviewNote,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Requests and Responses
+
+type GetLocationFlagsRequest struct {
+	Status     string `json:"status" form:"status"` // comma separated
+	Type       string `json:"type" form:"type"`     // comma separated
+	PartnerID  string `json:"partnerId" form:"partnerId"`
+	DeliveryID string `json:"deliveryId" form:"deliveryId"`
+	Limit      int    `json:"limit" form:"limit" binding:"gte=1"`
+	Offset     int    `json:"offset" form:"offset" binding:"gte=0"`
+}
+
+type GetLocationFlagsResponse struct {
+	Success     bool           `json:"success"`
+	Flags       []LocationFlag `json:"flags"`
+	Total       int            `json:"total"`
+	Limit       int            `json:"limit"`
+	Offset      int            `json:"offset"`
+	HasNext     bool           `json:"hasNext"`
+	HasPrevious bool           `json:"hasPrevious"`
+}
+
+type ReviewLocationFlagRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=dismiss confirm"`
+	Note     string `json:"note" binding:"max=1000"`
+}
+
+type LocationFlagResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message,omitempty"`
+	Flag    *LocationFlag `json:"flag,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
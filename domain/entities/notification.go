@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// Notification is an alert sent through a services.Notifier, to support staff
+// unless Audience says otherwise
+type Notification struct {
+	Type       string            `json:"type"`     // e.g. incident.reported
+	Severity   string            `json:"severity"` // low, medium, high, critical
+	Audience   string            `json:"audience"` // support, partner or customer
+	Title      string            `json:"title"`
+	Message    string            `json:"message"`
+	DeliveryID string            `json:"deliveryId,omitempty"`
+	OrderID    string            `json:"orderId,omitempty"`
+	PartnerID  string            `json:"partnerId,omitempty"`
+	CustomerID string            `json:"customerId,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt"`
+}
+
+// Notification audiences
+const (
+	AudienceSupport  = "support"
+	AudiencePartner  = "partner"
+	AudienceCustomer = "customer"
+)
@@ -0,0 +1,64 @@
+package entities
+
+import "time"
+
+// Offer asks a partner to take a pending delivery before ExpiresAt. A delivery
+// can be offered to several partners at once; the first to accept gets it.
+type Offer struct {
+	OfferID      string     `json:"id" bson:"_id,omitempty"`
+	DeliveryID   string     `json:"deliveryId" bson:"deliveryId"`
+	OrderID      string     `json:"orderId" bson:"orderId"`
+	PartnerID    string     `json:"partnerId" bson:"partnerId"`
+	Score        float64    `json:"score" bson:"score"`
+	Status       string     `json:"status" bson:"status"` // pending, accepted, rejected, expired, withdrawn
+	ExpiresAt    time.Time  `json:"expiresAt" bson:"expiresAt"`
+	RespondedAt  *time.Time `json:"respondedAt,omitempty" bson:"respondedAt,omitempty"`
+	RejectReason string     `json:"rejectReason,omitempty" bson:"rejectReason,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// OfferStats summarises how a partner responded to offers over a period
+type OfferStats struct {
+	PartnerID      string  `json:"partnerId"`
+	Period         string  `json:"period"`
+	Offered        int     `json:"offered"`
+	Accepted       int     `json:"accepted"`
+	Rejected       int     `json:"rejected"`
+	Expired        int     `json:"expired"`
+	Withdrawn      int     `json:"withdrawn"` // another partner accepted first
+	Pending        int     `json:"pending"`
+	AcceptanceRate float64 `json:"acceptanceRate"` // accepted / answered-or-expired offers
+	RejectionRate  float64 `json:"rejectionRate"`
+}
+
+// Requests and Responses
+
+type RejectOrderRequest struct {
+	Reason string `json:"reason" binding:"required,max=200"`
+}
+
+type OfferListItem struct {
+	OfferID         string     `json:"id"`
+	DeliveryID      string     `json:"deliveryId"`
+	OrderID         string     `json:"orderId"`
+	PickupAddress   string     `json:"pickupAddress"`
+	DeliveryAddress string     `json:"deliveryAddress"`
+	Distance        float64    `json:"distance"`
+	DeliveryFee     int        `json:"deliveryFee"`
+	ItemsCount      int        `json:"itemsCount"`
+	WindowStart     *time.Time `json:"windowStart,omitempty"`
+	WindowEnd       *time.Time `json:"windowEnd,omitempty"`
+	ExpiresAt       time.Time  `json:"expiresAt"`
+}
+
+type GetOffersResponse struct {
+	Success bool            `json:"success"`
+	Offers  []OfferListItem `json:"offers"`
+	Count   int             `json:"count"`
+}
+
+type GetOfferStatsResponse struct {
+	Success bool        `json:"success"`
+	Stats   *OfferStats `json:"stats,omitempty"`
+}
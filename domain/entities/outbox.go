@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// OutboxMessage is an event written in the same transaction as the state change
+// that produced it. The relay publishes it afterwards.
+type OutboxMessage struct {
+	MessageID     string        `json:"id" bson:"_id,omitempty"`
+	Event         DeliveryEvent `json:"event" bson:"event"`
+	Status        string        `json:"status" bson:"status"` // pending, published
+	Attempts      int           `json:"attempts" bson:"attempts"`
+	NextAttemptAt time.Time     `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	LastError     string        `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	PublishedAt   *time.Time    `json:"publishedAt,omitempty" bson:"publishedAt,omitempty"`
+	CreatedAt     time.Time     `json:"createdAt" bson:"createdAt"`
+}
@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+// Coordinate is a latitude/longitude pair
+type Coordinate struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Route is the travel distance and time between two coordinates as reported
+// by a route provider
+type Route struct {
+	DistanceKm      float64 `json:"distanceKm"`
+	DurationSeconds int     `json:"durationSeconds"`
+	Source          string  `json:"source"` // provider that computed it, e.g. osrm, haversine
+}
+
+// RouteMatrix holds the travel distance and time between every pair of
+// points, indexed [from][to]
+type RouteMatrix struct {
+	DistancesKm      [][]float64 `json:"distancesKm"`
+	DurationsSeconds [][]int     `json:"durationsSeconds"`
+	Source           string      `json:"source"`
+}
+
+// RouteStop is one stop in a partner's planned run
+type RouteStop struct {
+	Sequence    int        `json:"sequence"`
+	Type        string     `json:"type"` // pickup, drop
+	DeliveryID  string     `json:"deliveryId"`
+	OrderID     string     `json:"orderId"`
+	Address     string     `json:"address"`
+	Latitude    float64    `json:"latitude"`
+	Longitude   float64    `json:"longitude"`
+	LegDistance float64    `json:"legDistance"` // km from the previous stop
+	ArrivalAt   time.Time  `json:"arrivalAt"`
+	WaitMinutes int        `json:"waitMinutes,omitempty"` // arriving before the window opens
+	LateMinutes int        `json:"lateMinutes,omitempty"` // arriving after the window or SLA deadline
+	WindowStart *time.Time `json:"windowStart,omitempty"`
+	WindowEnd   *time.Time `json:"windowEnd,omitempty"`
+}
+
+type GetActiveRouteResponse struct {
+	Success       bool        `json:"success"`
+	Message       string      `json:"message,omitempty"`
+	Stops         []RouteStop `json:"stops"`
+	Count         int         `json:"count"`
+	TotalDistance float64     `json:"totalDistance"` // km
+	FinishAt      *time.Time  `json:"finishAt,omitempty"`
+	RouteSource   string      `json:"routeSource,omitempty"`
+	Error         string      `json:"error,omitempty"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// StreamEvent is one frame pushed to live tracking subscribers
+type StreamEvent struct {
+	ID         string          `json:"id,omitempty"`
+	Type       string          `json:"type"` // status or location
+	DeliveryID string          `json:"deliveryId,omitempty"`
+	OrderID    string          `json:"orderId,omitempty"`
+	PartnerID  string          `json:"partnerId,omitempty"`
+	Status     string          `json:"status,omitempty"`
+	Location   *StreamLocation `json:"location,omitempty"`
+	At         time.Time       `json:"at"`
+}
+
+type StreamLocation struct {
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	Speed      *float64  `json:"speed,omitempty"`
+	Heading    *float64  `json:"heading,omitempty"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// Requests
+
+type OpsStreamRequest struct {
+	DeliveryID string `json:"deliveryId" form:"deliveryId"`
+	PartnerID  string `json:"partnerId" form:"partnerId"`
+}
@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+// Customer-facing tracking view, served without authentication
+
+type TrackingPartner struct {
+	FirstName   string `json:"firstName"`
+	VehicleType string `json:"vehicleType,omitempty"`
+}
+
+// TrackingLocation is rounded to roughly 100 m before it leaves the service
+type TrackingLocation struct {
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type TrackingResponse struct {
+	Success     bool              `json:"success"`
+	OrderID     string            `json:"orderId,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	ETA         *time.Time        `json:"eta,omitempty"`
+	WindowStart *time.Time        `json:"windowStart,omitempty"`
+	WindowEnd   *time.Time        `json:"windowEnd,omitempty"`
+	DeliveredAt *time.Time        `json:"deliveredAt,omitempty"`
+	Partner     *TrackingPartner  `json:"partner,omitempty"`
+	Location    *TrackingLocation `json:"location,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+type TrackingLinkResponse struct {
+	Success   bool      `json:"success"`
+	Token     string    `json:"token,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
@@ -0,0 +1,19 @@
+package entities
+
+// WatchdogAction is what the watchdog did about one stranded or stalled order
+type WatchdogAction struct {
+	DeliveryID string `json:"deliveryId"`
+	OrderID    string `json:"orderId"`
+	PartnerID  string `json:"partnerId"`
+	Status     string `json:"status"`
+	Action     string `json:"action"` // released, reassigned, offered, alerted, failed
+	Reason     string `json:"reason"`
+}
+
+type WatchdogRunResponse struct {
+	Success bool             `json:"success"`
+	Policy  string           `json:"policy"`
+	Actions []WatchdogAction `json:"actions"`
+	Count   int              `json:"count"`
+	Error   string           `json:"error,omitempty"`
+}
@@ -0,0 +1,82 @@
+package entities
+
+import "time"
+
+// DeliveryEvent is emitted whenever a delivery moves through its lifecycle
+type DeliveryEvent struct {
+	EventID    string                 `json:"id" bson:"eventId"`
+	Type       string                 `json:"type" bson:"type"` // delivery.assigned, delivery.picked_up, delivery.in_transit, delivery.delivered
+	DeliveryID string                 `json:"deliveryId" bson:"deliveryId"`
+	OrderID    string                 `json:"orderId" bson:"orderId"`
+	PartnerID  string                 `json:"partnerId" bson:"partnerId"`
+	Status     string                 `json:"status" bson:"status"`
+	OccurredAt time.Time              `json:"occurredAt" bson:"occurredAt"`
+	Data       map[string]interface{} `json:"data,omitempty" bson:"data,omitempty"`
+}
+
+// WebhookSubscription is an external endpoint that receives delivery events
+type WebhookSubscription struct {
+	SubscriptionID string    `json:"id" bson:"_id,omitempty"`
+	Name           string    `json:"name" bson:"name"`
+	URL            string    `json:"url" bson:"url"`
+	Secret         string    `json:"-" bson:"secret"`
+	Events         []string  `json:"events" bson:"events"` // empty means all events
+	IsActive       bool      `json:"isActive" bson:"isActive"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// WebhookMessage is one event queued for one subscriber. Messages that run out
+// of attempts stay in the store with status "dead" until they are redelivered.
+type WebhookMessage struct {
+	MessageID      string        `json:"id" bson:"_id,omitempty"`
+	SubscriptionID string        `json:"subscriptionId" bson:"subscriptionId"`
+	URL            string        `json:"url" bson:"url"`
+	Event          DeliveryEvent `json:"event" bson:"event"`
+	Status         string        `json:"status" bson:"status"` // pending, delivered, dead
+	Attempts       int           `json:"attempts" bson:"attempts"`
+	NextAttemptAt  time.Time     `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	LastStatusCode int           `json:"lastStatusCode,omitempty" bson:"lastStatusCode,omitempty"`
+	LastError      string        `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	DeliveredAt    *time.Time    `json:"deliveredAt,omitempty" bson:"deliveredAt,omitempty"`
+	DeadAt         *time.Time    `json:"deadAt,omitempty" bson:"deadAt,omitempty"`
+	CreatedAt      time.Time     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Requests and Responses
+
+type CreateWebhookSubscriptionRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	URL    string   `json:"url" binding:"required,url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"` // generated when empty
+}
+
+type CreateWebhookSubscriptionResponse struct {
+	Success      bool                 `json:"success"`
+	Subscription *WebhookSubscription `json:"subscription,omitempty"`
+	Secret       string               `json:"secret,omitempty"` // only returned once
+	Error        string               `json:"error,omitempty"`
+}
+
+type GetWebhookSubscriptionsResponse struct {
+	Success       bool                  `json:"success"`
+	Subscriptions []WebhookSubscription `json:"subscriptions"`
+	Count         int                   `json:"count"`
+}
+
+type GetDeadLettersRequest struct {
+	Limit  int `json:"limit" form:"limit" binding:"gte=1"`
+	Offset int `json:"offset" form:"offset" binding:"gte=0"`
+}
+
+type GetDeadLettersResponse struct {
+	Success     bool             `json:"success"`
+	Messages    []WebhookMessage `json:"messages"`
+	Total       int              `json:"total"`
+	Limit       int              `json:"limit"`
+	Offset      int              `json:"offset"`
+	HasNext     bool             `json:"hasNext"`
+	HasPrevious bool             `json:"hasPrevious"`
+}
@@ -0,0 +1,62 @@
+package entities
+
+import "time"
+
+// Zone is an area served by one or more warehouses
+type Zone struct {
+	ZoneID       string     `json:"id" bson:"_id,omitempty"`
+	Name         string     `json:"name" bson:"name"`
+	WarehouseIDs []string   `json:"warehouseIds" bson:"warehouseIds"`
+	Area         GeoPolygon `json:"area" bson:"area"`
+	IsActive     bool       `json:"isActive" bson:"isActive"`
+	CreatedAt    time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Requests and Responses
+
+type ZoneRequest struct {
+	Name         string     `json:"name" binding:"required,max=100"`
+	WarehouseIDs []string   `json:"warehouseIds" binding:"required,min=1,dive,required"`
+	Area         GeoPolygon `json:"area" binding:"required"`
+	IsActive     *bool      `json:"isActive"` // defaults to true
+}
+
+type ZoneResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	Zone    *Zone  `json:"zone,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type GetZonesRequest struct {
+	WarehouseID     string `json:"warehouseId" form:"warehouseId"`
+	IncludeInactive bool   `json:"includeInactive" form:"includeInactive"`
+}
+
+type GetZonesResponse struct {
+	Success bool   `json:"success"`
+	Zones   []Zone `json:"zones"`
+	Count   int    `json:"count"`
+}
+
+type SetHomeZoneRequest struct {
+	ZoneID string `json:"zoneId"` // empty clears the home zone
+}
+
+type ServiceabilityRequest struct {
+	Latitude  float64 `json:"latitude" form:"latitude" binding:"required,latitude"`
+	Longitude float64 `json:"longitude" form:"longitude" binding:"required,longitude"`
+}
+
+type ServiceableZone struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	WarehouseIDs []string `json:"warehouseIds"`
+}
+
+type ServiceabilityResponse struct {
+	Success     bool              `json:"success"`
+	Serviceable bool              `json:"serviceable"`
+	Zones       []ServiceableZone `json:"zones"`
+}
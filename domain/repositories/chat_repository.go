@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"time"
+)
+
+type ChatRepository interface {
+	Create(message *entities.ChatMessage) error
+
+	// GetMessages returns the thread oldest first. When afterID is set only
+	// messages sent after it are returned.
+	GetMessages(deliveryID, afterID string, limit int) ([]entities.ChatMessage, error)
+
+	// MarkRead adds a read receipt for readerRole to every message sent by
+	// someone else that the role has not read yet
+	MarkRead(deliveryID, readerRole string, readAt time.Time) (int, error)
+	CountUnread(deliveryID, readerRole string) (int, error)
+}
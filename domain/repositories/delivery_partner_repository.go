@@ -2,12 +2,22 @@ package repositories
 
 import (
 	"deliveryAppBackend/domain/entities"
+	"time"
 )
 
+// PartnerFilter narrows the fleet list. A non-nil Zones keeps only partners
+// based in or currently inside one of the zones.
+type PartnerFilter struct {
+	IsAvailable *bool
+	Zones       []entities.Zone
+}
+
 type DeliveryPartnerRepository interface {
 	// Authentication
 	FindByPhoneNumber(phoneNumber string) (*entities.DeliveryPartner, error)
 	FindByID(partnerID string) (*entities.DeliveryPartner, error)
+	// FindByIDs returns the partners found, keyed by ID; missing IDs are left out
+	FindByIDs(partnerIDs []string) (map[string]*entities.DeliveryPartner, error)
 	Create(partner *entities.DeliveryPartner) error
 	Update(partner *entities.DeliveryPartner) error
 	
@@ -18,7 +28,28 @@ type DeliveryPartnerRepository interface {
 	// Profile Management
 	UpdateProfile(partnerID string, updates map[string]interface{}) error
 	UpdateLocation(partnerID string, latitude, longitude float64) error
+	// UpdateLocationIfNewer moves the partner only if recordedAt is after their
+	// LastLocationAt, and reports whether it did
+	UpdateLocationIfNewer(partnerID string, latitude, longitude float64, recordedAt time.Time) (bool, error)
 	ToggleAvailability(partnerID string, isAvailable bool) error
+
+	// Presence
+	Heartbeat(partnerID string) (*entities.DeliveryPartner, error)
+	// SetBreak only applies to available partners, and reports whether it did
+	SetBreak(partnerID string, onBreak bool) (bool, error)
+	// SweepPresence marks partners silent since staleBefore stale and those
+	// silent since offlineBefore offline, and brings stale partners who have
+	// been heard from again back online. Partners in holdingOrders go no
+	// further than stale; their orders are the watchdog's call.
+	SweepPresence(staleBefore, offlineBefore time.Time, holdingOrders []string) (*entities.PresenceSweep, error)
+
+	// Dispatch
+	// FindAvailablePartnersNear returns verified, available partners within
+	// radiusKm of the point who sent a location since locatedSince, nearest first
+	FindAvailablePartnersNear(point entities.GeoPoint, radiusKm float64, locatedSince time.Time, limit int) ([]entities.DeliveryPartner, error)
+
+	// Fleet Console
+	FindPartners(filter *PartnerFilter, limit, offset int) ([]entities.DeliveryPartner, int, error)
 	
 	// Statistics
 	GetTotalDeliveries(partnerID string) (int, error)
@@ -2,8 +2,19 @@ package repositories
 
 import (
 	"deliveryAppBackend/domain/entities"
+	"time"
 )
 
+// DeliveryFilter narrows the fleet console order lists. A non-nil Zones keeps
+// only orders from the zones' warehouses or picked up inside one of them.
+type DeliveryFilter struct {
+	Statuses    []string
+	Unassigned  bool
+	SLAStatuses []string
+	WarehouseID string
+	Zones       []entities.Zone
+}
+
 type DeliveryRepository interface {
 	// Order Management
 	GetActiveOrdersByPartner(partnerID string) ([]entities.Delivery, error)
@@ -14,15 +25,60 @@ type DeliveryRepository interface {
 	Update(delivery *entities.Delivery) error
 	
 	// Status Updates
-	AcceptOrder(deliveryID, partnerID string) error
-	UpdateStatus(deliveryID, status string) error
-	CompleteDelivery(deliveryID string, notes string) error
+	// Each transition writes its event to the outbox in the same transaction
+	AcceptOrder(deliveryID, partnerID string, capacity *entities.CapacityCheck, tracking *entities.TrackingLinkResponse, entry *entities.TimelineEntry, event *entities.DeliveryEvent) error
+	// UpdateStatus only moves the order on if it is still in fromStatus with the partner
+	UpdateStatus(deliveryID, partnerID, fromStatus, status string, event *entities.DeliveryEvent) error
+	CompleteDelivery(deliveryID string, notes string, slaStatus string, earnings *entities.Earnings, event *entities.DeliveryEvent) error
+	
+	// Pickup Handover
+	RecordPickupScan(deliveryID string, verification *entities.PickupVerification) error
+	ConfirmPickup(deliveryID, confirmedBy, note string, override bool, event *entities.DeliveryEvent) error
 	
 	// Assignment
 	AssignToPartner(orderID, partnerID string) error
-	GetPendingOrders() ([]entities.Delivery, error)
+	// ReleaseOrder takes a pending or assigned order back from the partner and
+	// returns it to the pool
+	ReleaseOrder(deliveryID, partnerID string, entry *entities.TimelineEntry, event *entities.DeliveryEvent) error
+	// ReassignOrder moves an order not picked up yet from one partner to
+	// another in one write, failing if fromPartnerID no longer holds it
+	ReassignOrder(deliveryID, fromPartnerID, toPartnerID string, capacity *entities.CapacityCheck, tracking *entities.TrackingLinkResponse, entry *entities.TimelineEntry, event *entities.DeliveryEvent) error
+	AddTimelineEntry(deliveryID string, entry *entities.TimelineEntry) error
+	// Scheduled orders are held until their window opens before windowOpensBefore
+	GetPendingOrders(windowOpensBefore time.Time) ([]entities.Delivery, error)
+	// FindPendingOrdersNear returns pending orders without a partner whose
+	// pickup is within radiusKm of the point, nearest first
+	FindPendingOrdersNear(point entities.GeoPoint, radiusKm float64, limit int) ([]entities.Delivery, error)
+	// CountActiveOrdersByPartners returns the number of open orders (offered or
+	// in progress) per partner. Partners without any are left out.
+	CountActiveOrdersByPartners(partnerIDs []string) (map[string]int, error)
+	// FindPartnersHoldingOrders returns the partners with an order assigned to
+	// them or on the road
+	FindPartnersHoldingOrders() ([]string, error)
+	// GetActiveOrdersByPartners returns the open orders (offered or in
+	// progress) of each partner. Partners without any are left out.
+	GetActiveOrdersByPartners(partnerIDs []string) (map[string][]entities.Delivery, error)
+	
+	// Customer Tracking
+	SetTrackingToken(deliveryID, token string, expiresAt time.Time) error
+	GetByTrackingToken(token string) (*entities.Delivery, error)
+	
+	// Customer Calls
+	AddCallAttempt(deliveryID string, attempt *entities.CallAttempt) error
+	
+	// SLA Tracking
+	GetInProgressDeliveries() ([]entities.Delivery, error)
+	UpdateSLA(deliveryID string, update *entities.DeliverySLAUpdate) error
+	GetDeliveriesBySLAStatus(slaStatuses []string, warehouseID string) ([]entities.Delivery, error)
+	
+	// Fleet Console
+	FindDeliveries(filter *DeliveryFilter, limit, offset int) ([]entities.Delivery, int, error)
+	
+	// Reconciliation
+	GetDeliveredWithoutEarnings(limit int) ([]entities.Delivery, error)
 	
 	// Statistics
 	GetDeliveriesCountByPartner(partnerID string, period string) (int, error)
+	GetSlotAdherence(groupBy, period, partnerID, warehouseID string) ([]entities.SlotAdherence, error)
 }
 
@@ -0,0 +1,22 @@
+package repositories
+
+import "errors"
+
+// ErrPartnerNotFound is returned when no partner has the given ID
+var ErrPartnerNotFound = errors.New("partner not found")
+
+// ErrDeliveryNotFound is returned when no delivery has the given ID
+var ErrDeliveryNotFound = errors.New("delivery not found")
+
+// ErrZoneNotFound is returned when no zone has the given ID
+var ErrZoneNotFound = errors.New("zone not found")
+
+// ErrDeliveryExists is returned by Create when the order already has a delivery
+var ErrDeliveryExists = errors.New("a delivery already exists for this order")
+
+// ErrFeedbackExists is returned by Create when the delivery already has feedback
+var ErrFeedbackExists = errors.New("feedback was already submitted for this delivery")
+
+// ErrCapacityExceeded is returned when an order no longer fits the partner's
+// vehicle at the moment it is assigned
+var ErrCapacityExceeded = errors.New("order does not fit the partner's remaining capacity")
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+)
+
+type FeedbackRepository interface {
+	// Create returns ErrFeedbackExists if the delivery already has feedback
+	Create(feedback *entities.Feedback) error
+	GetByID(feedbackID string) (*entities.Feedback, error)
+	GetByDeliveryID(deliveryID string) (*entities.Feedback, error)
+	GetByPartner(partnerID string, limit, offset int) ([]entities.Feedback, int, error)
+
+	// GetRatedFeedback returns every feedback for the partner that counts
+	// towards the rating, i.e. is not excluded
+	GetRatedFeedback(partnerID string) ([]entities.Feedback, error)
+	SetExcluded(feedbackID string, excluded bool, actor, reason string) error
+}
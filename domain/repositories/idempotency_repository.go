@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	// Reserve stores the record if its key is unused. When the key already
+	// exists the stored record is returned instead and nothing is written.
+	Reserve(record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error)
+	// Complete stores the response and keeps it until expiresAt
+	Complete(key string, responseStatus int, responseBody []byte, contentType string, expiresAt time.Time) error
+	Release(key string) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+)
+
+type IncidentFilter struct {
+	Statuses   []string
+	Severities []string
+	Category   string
+	PartnerID  string
+	DeliveryID string
+}
+
+type IncidentRepository interface {
+	Create(incident *entities.Incident) error
+	GetByID(incidentID string) (*entities.Incident, error)
+	GetByDelivery(deliveryID string) ([]entities.Incident, error)
+	Find(filter *IncidentFilter, limit, offset int) ([]entities.Incident, int, error)
+
+	// Acknowledge and Resolve only apply to incidents in a state that allows
+	// the transition and report whether one was updated
+	Acknowledge(incidentID, actor string) (bool, error)
+	Resolve(incidentID, actor, resolution string) (bool, error)
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+)
+
+type LocationFlagFilter struct {
+	Statuses   []string
+	Types      []string
+	PartnerID  string
+	DeliveryID string
+}
+
+type LocationFlagRepository interface {
+	// Create stores the flag and counts it against the partner and its deliveries
+	Create(flag *entities.LocationFlag) error
+	GetByID(flagID string) (*entities.LocationFlag, error)
+	Find(filter *LocationFlagFilter, limit, offset int) ([]entities.LocationFlag, int, error)
+	// HasOpen reports whether the partner already has an open flag of this
+	// type for exactly these deliveries
+	HasOpen(partnerID, flagType string, deliveryIDs []string) (bool, error)
+
+	// Review closes an open flag as dismissed or confirmed, takes it off the
+	// partner's and deliveries' counts, and reports whether one was updated
+	Review(flagID, status, actor, note string) (bool, error)
+}
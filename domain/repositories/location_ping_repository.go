@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"time"
+)
+
+type LocationPingRepository interface {
+	Insert(ping *entities.LocationPing) error
+	InsertMany(pings []entities.LocationPing) error
+	// Claim reserves the partner's fixes by recordedAt, to the millisecond, and
+	// returns the ones nobody claimed before. Concurrent uploads of the same
+	// fixes never both get one back.
+	Claim(partnerID string, recordedAts []time.Time) ([]time.Time, error)
+	// Release gives back claims whose pings failed to store
+	Release(partnerID string, recordedAts []time.Time) error
+	// GetLatest returns the partner's last ping recorded before the given time, or nil
+	GetLatest(partnerID string, before time.Time) (*entities.LocationPing, error)
+
+	// GetTrail returns a partner's pings recorded in [from, to], oldest first
+	GetTrail(partnerID string, from, to time.Time, limit int) ([]entities.LocationPing, error)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"time"
+)
+
+type OfferRepository interface {
+	CreateMany(offers []entities.Offer) error
+	GetPendingByDelivery(deliveryID string) ([]entities.Offer, error)
+	GetPendingByPartner(partnerID string) ([]entities.Offer, error)
+	// GetDeliveriesWithPendingOffers reports which of the deliveries still
+	// have an offer waiting for an answer
+	GetDeliveriesWithPendingOffers(deliveryIDs []string) (map[string]bool, error)
+	// CountPendingByPartners returns how many offers each partner has waiting
+	// for an answer
+	CountPendingByPartners(partnerIDs []string) (map[string]int, error)
+	// GetOfferedPartners returns the partners offered the delivery since the given time
+	GetOfferedPartners(deliveryID string, since time.Time) ([]string, error)
+
+	// Respond closes a pending offer with the partner's answer and reports
+	// whether it was still pending
+	Respond(offerID, status, reason string) (bool, error)
+	// WithdrawPending closes the remaining offers once a delivery is taken
+	WithdrawPending(deliveryID string) error
+	// ExpireDue marks pending offers past their expiry as expired
+	ExpireDue(now time.Time) (int, error)
+
+	GetStatsByPartner(partnerID, period string) (*entities.OfferStats, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"time"
+)
+
+// OutboxRepository reads the outbox. Messages are written by the repositories
+// that own the state change, inside the same transaction.
+type OutboxRepository interface {
+	ClaimPending(limit int, lease time.Duration) ([]entities.OutboxMessage, error)
+	MarkPublished(messageID string) error
+	MarkFailed(messageID string, attempts int, nextAttemptAt time.Time, lastError string) error
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"time"
+)
+
+type WebhookRepository interface {
+	// Subscriptions
+	CreateSubscription(subscription *entities.WebhookSubscription) error
+	GetSubscriptions(activeOnly bool) ([]entities.WebhookSubscription, error)
+	DeleteSubscription(subscriptionID string) error
+
+	// Message Queue
+	EnqueueMessages(messages []entities.WebhookMessage) error
+	ClaimDueMessages(limit int, lease time.Duration) ([]entities.WebhookMessage, error)
+	MarkDelivered(messageID string, attempts int, statusCode int) error
+	MarkRetry(messageID string, attempts int, nextAttemptAt time.Time, statusCode int, lastError string) error
+	MarkDead(messageID string, attempts int, statusCode int, lastError string) error
+
+	// Dead Letters
+	GetDeadLetters(limit, offset int) ([]entities.WebhookMessage, int, error)
+	Redeliver(messageID string) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"deliveryAppBackend/domain/entities"
+)
+
+type ZoneRepository interface {
+	Create(zone *entities.Zone) error
+	GetByID(zoneID string) (*entities.Zone, error)
+	Update(zone *entities.Zone) error
+	GetAll(warehouseID string, includeInactive bool) ([]entities.Zone, error)
+
+	// Lookups only return active zones
+	FindContaining(point entities.GeoPoint) ([]entities.Zone, error)
+	GetByWarehouse(warehouseID string) ([]entities.Zone, error)
+}
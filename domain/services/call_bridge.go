@@ -0,0 +1,12 @@
+package services
+
+import (
+	"deliveryAppBackend/domain/entities"
+)
+
+// CallBridge connects a partner to a customer through a proxy number so that
+// neither side sees the other's real phone number.
+type CallBridge interface {
+	Name() string
+	CreateSession(req *entities.CallBridgeRequest) (*entities.CallBridgeSession, error)
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"deliveryAppBackend/domain/entities"
+)
+
+// Notifier alerts support staff about things that need a human, e.g. a
+// high-severity incident reported by a partner. Notifications for a partner
+// or customer carry their audience so the receiver can push them on.
+type Notifier interface {
+	Name() string
+	Notify(notification *entities.Notification) error
+}
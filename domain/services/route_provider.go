@@ -0,0 +1,12 @@
+package services
+
+import (
+	"deliveryAppBackend/domain/entities"
+)
+
+// RouteProvider computes road distance and travel time between two points.
+type RouteProvider interface {
+	Name() string
+	Route(from, to entities.Coordinate) (*entities.Route, error)
+	Matrix(points []entities.Coordinate) (*entities.RouteMatrix, error)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CallHandler struct {
+	callUseCase *usecase.CallUseCase
+}
+
+func NewCallHandler(callUseCase *usecase.CallUseCase) *CallHandler {
+	return &CallHandler{
+		callUseCase: callUseCase,
+	}
+}
+
+func (h *CallHandler) CallCustomer(c *gin.Context) {
+	deliveryID := c.Param("id")
+	partnerID := c.GetString("partnerId")
+
+	response, err := h.callUseCase.CallCustomer(deliveryID, partnerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChatHandler struct {
+	chatUseCase *usecase.ChatUseCase
+}
+
+func NewChatHandler(chatUseCase *usecase.ChatUseCase) *ChatHandler {
+	return &ChatHandler{
+		chatUseCase: chatUseCase,
+	}
+}
+
+// Partner
+
+func (h *ChatHandler) GetPartnerMessages(c *gin.Context) {
+	deliveryID := c.Param("id")
+	partnerID := c.GetString("partnerId")
+
+	var req entities.GetMessagesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.chatUseCase.GetPartnerMessages(c.Request.Context(), deliveryID, partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChatHandler) SendPartnerMessage(c *gin.Context) {
+	deliveryID := c.Param("id")
+	partnerID := c.GetString("partnerId")
+
+	var req entities.SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.chatUseCase.SendPartnerMessage(deliveryID, partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChatHandler) MarkPartnerRead(c *gin.Context) {
+	deliveryID := c.Param("id")
+	partnerID := c.GetString("partnerId")
+
+	response, err := h.chatUseCase.MarkPartnerRead(deliveryID, partnerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChatHandler) GetPartnerQuickReplies(c *gin.Context) {
+	c.JSON(http.StatusOK, h.chatUseCase.GetQuickReplies("partner"))
+}
+
+// Customer (tracking token)
+
+func (h *ChatHandler) GetCustomerMessages(c *gin.Context) {
+	token := c.Param("token")
+
+	var req entities.GetMessagesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.chatUseCase.GetCustomerMessages(c.Request.Context(), token, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if response.Error != "" {
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChatHandler) SendCustomerMessage(c *gin.Context) {
+	token := c.Param("token")
+
+	var req entities.SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.chatUseCase.SendCustomerMessage(token, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if response.Error != "" {
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChatHandler) MarkCustomerRead(c *gin.Context) {
+	token := c.Param("token")
+
+	response, err := h.chatUseCase.MarkCustomerRead(token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if response.Error != "" {
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChatHandler) GetCustomerQuickReplies(c *gin.Context) {
+	c.JSON(http.StatusOK, h.chatUseCase.GetQuickReplies("customer"))
+}
+
+// Support
+
+func (h *ChatHandler) GetSupportMessages(c *gin.Context) {
+	deliveryID := c.Param("id")
+
+	var req entities.GetMessagesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.chatUseCase.GetSupportMessages(c.Request.Context(), deliveryID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChatHandler) SendSupportMessage(c *gin.Context) {
+	deliveryID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	var req entities.SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.chatUseCase.SendSupportMessage(deliveryID, actor, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -33,6 +33,64 @@ func (h *DeliveryHandler) GetActiveOrders(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *DeliveryHandler) CreateDelivery(c *gin.Context) {
+	var req entities.CreateDeliveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.deliveryUseCase.CreateDelivery(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *DeliveryHandler) GetNearbyOrders(c *gin.Context) {
+	partnerID := c.GetString("partnerId")
+
+	req := entities.GetNearbyOrdersRequest{
+		RadiusKm: 3,
+		Limit:    20,
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.deliveryUseCase.GetNearbyOrders(partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *DeliveryHandler) GetActiveRoute(c *gin.Context) {
+	partnerID := c.GetString("partnerId")
+
+	response, err := h.deliveryUseCase.GetActiveRoute(partnerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 func (h *DeliveryHandler) GetOrderHistory(c *gin.Context) {
 	partnerID := c.GetString("partnerId")
 	
@@ -132,3 +190,88 @@ func (h *DeliveryHandler) CompleteDelivery(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+
+func (h *DeliveryHandler) SubmitPickupScan(c *gin.Context) {
+	deliveryID := c.Param("id")
+	partnerID := c.GetString("partnerId")
+
+	var req entities.PickupScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.deliveryUseCase.SubmitPickupScan(deliveryID, partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *DeliveryHandler) ConfirmPickup(c *gin.Context) {
+	deliveryID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	var req entities.ConfirmPickupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.deliveryUseCase.ConfirmPickup(deliveryID, actor, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *DeliveryHandler) GetMySlotAdherence(c *gin.Context) {
+	req := entities.GetSlotAdherenceRequest{
+		Period:    c.DefaultQuery("period", "week"),
+		GroupBy:   "partner",
+		PartnerID: c.GetString("partnerId"),
+	}
+
+	response, err := h.deliveryUseCase.GetSlotAdherence(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *DeliveryHandler) GetSlotAdherenceReport(c *gin.Context) {
+	var req entities.GetSlotAdherenceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.deliveryUseCase.GetSlotAdherence(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DispatchHandler struct {
+	dispatcher usecase.Dispatcher
+}
+
+func NewDispatchHandler(dispatcher usecase.Dispatcher) *DispatchHandler {
+	return &DispatchHandler{
+		dispatcher: dispatcher,
+	}
+}
+
+// RunDispatch triggers a dispatch pass without waiting for the next tick
+func (h *DispatchHandler) RunDispatch(c *gin.Context) {
+	response, err := h.dispatcher.Dispatch()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *DispatchHandler) GetCandidates(c *gin.Context) {
+	deliveryID := c.Param("id")
+
+	response, err := h.dispatcher.RankCandidates(deliveryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
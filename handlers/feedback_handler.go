@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedbackHandler struct {
+	feedbackUseCase *usecase.FeedbackUseCase
+}
+
+func NewFeedbackHandler(feedbackUseCase *usecase.FeedbackUseCase) *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackUseCase: feedbackUseCase,
+	}
+}
+
+func (h *FeedbackHandler) SubmitCustomerFeedback(c *gin.Context) {
+	token := c.Param("token")
+
+	var req entities.SubmitFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.feedbackUseCase.SubmitCustomerFeedback(token, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if response.Error != "" {
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FeedbackHandler) SubmitOrderFeedback(c *gin.Context) {
+	deliveryID := c.Param("id")
+
+	var req entities.SubmitFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.feedbackUseCase.SubmitOrderFeedback(deliveryID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FeedbackHandler) GetPartnerFeedback(c *gin.Context) {
+	partnerID := c.Param("id")
+
+	var req entities.GetPartnerFeedbackRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.feedbackUseCase.GetPartnerFeedback(partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FeedbackHandler) ExcludeFeedback(c *gin.Context) {
+	feedbackID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	var req entities.ExcludeFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.feedbackUseCase.ExcludeFeedback(feedbackID, actor, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FeedbackHandler) IncludeFeedback(c *gin.Context) {
+	feedbackID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	response, err := h.feedbackUseCase.IncludeFeedback(feedbackID, actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FleetHandler struct {
+	fleetUseCase *usecase.FleetUseCase
+}
+
+func NewFleetHandler(fleetUseCase *usecase.FleetUseCase) *FleetHandler {
+	return &FleetHandler{
+		fleetUseCase: fleetUseCase,
+	}
+}
+
+func (h *FleetHandler) GetPartners(c *gin.Context) {
+	var req entities.GetFleetPartnersRequest
+	req.Limit = 50 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.fleetUseCase.GetPartners(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if !response.Success {
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FleetHandler) GetUnassignedOrders(c *gin.Context) {
+	var req entities.GetFleetOrdersRequest
+	req.Limit = 50 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.fleetUseCase.GetUnassignedOrders(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if !response.Success {
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FleetHandler) GetAtRiskOrders(c *gin.Context) {
+	var req entities.GetFleetOrdersRequest
+	req.Limit = 50 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.fleetUseCase.GetAtRiskOrders(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if !response.Success {
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FleetHandler) AssignOrder(c *gin.Context) {
+	deliveryID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	var req entities.ManualAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.fleetUseCase.AssignOrder(deliveryID, actor, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FleetHandler) UnassignOrder(c *gin.Context) {
+	deliveryID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	var req entities.ManualUnassignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.fleetUseCase.UnassignOrder(deliveryID, actor, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *FleetHandler) ReassignOrder(c *gin.Context) {
+	deliveryID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	var req entities.ManualAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.fleetUseCase.ReassignOrder(deliveryID, actor, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
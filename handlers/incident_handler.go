@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IncidentHandler struct {
+	incidentUseCase *usecase.IncidentUseCase
+}
+
+func NewIncidentHandler(incidentUseCase *usecase.IncidentUseCase) *IncidentHandler {
+	return &IncidentHandler{
+		incidentUseCase: incidentUseCase,
+	}
+}
+
+func (h *IncidentHandler) ReportIncident(c *gin.Context) {
+	deliveryID := c.Param("id")
+	partnerID := c.GetString("partnerId")
+
+	var req entities.ReportIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.incidentUseCase.ReportIncident(deliveryID, partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *IncidentHandler) GetDeliveryIncidents(c *gin.Context) {
+	deliveryID := c.Param("id")
+	partnerID := c.GetString("partnerId")
+
+	response, err := h.incidentUseCase.GetDeliveryIncidents(deliveryID, partnerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *IncidentHandler) GetIncidents(c *gin.Context) {
+	var req entities.GetIncidentsRequest
+	req.Limit = 50 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.incidentUseCase.GetIncidents(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *IncidentHandler) AcknowledgeIncident(c *gin.Context) {
+	incidentID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	response, err := h.incidentUseCase.AcknowledgeIncident(incidentID, actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *IncidentHandler) ResolveIncident(c *gin.Context) {
+	incidentID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	var req entities.ResolveIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.incidentUseCase.ResolveIncident(incidentID, actor, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
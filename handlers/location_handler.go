@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationHandler struct {
+	locationUseCase *usecase.LocationUseCase
+}
+
+func NewLocationHandler(locationUseCase *usecase.LocationUseCase) *LocationHandler {
+	return &LocationHandler{
+		locationUseCase: locationUseCase,
+	}
+}
+
+func (h *LocationHandler) UpdateLocation(c *gin.Context) {
+	partnerID := c.GetString("partnerId")
+
+	var req entities.UpdateLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.locationUseCase.UpdateLocation(partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *LocationHandler) UploadLocationBatch(c *gin.Context) {
+	partnerID := c.GetString("partnerId")
+
+	var req entities.LocationBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.locationUseCase.UploadLocationBatch(partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *LocationHandler) GetPartnerLocations(c *gin.Context) {
+	partnerID := c.Param("id")
+
+	var req entities.GetPartnerLocationsRequest
+	req.Limit = 1000 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.locationUseCase.GetPartnerLocations(partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *LocationHandler) GetDeliveryTrail(c *gin.Context) {
+	deliveryID := c.Param("id")
+
+	response, err := h.locationUseCase.GetDeliveryTrail(deliveryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
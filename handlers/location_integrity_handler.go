@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationIntegrityHandler struct {
+	integrityUseCase *usecase.LocationIntegrityUseCase
+}
+
+func NewLocationIntegrityHandler(integrityUseCase *usecase.LocationIntegrityUseCase) *LocationIntegrityHandler {
+	return &LocationIntegrityHandler{
+		integrityUseCase: integrityUseCase,
+	}
+}
+
+func (h *LocationIntegrityHandler) GetLocationFlags(c *gin.Context) {
+	var req entities.GetLocationFlagsRequest
+	req.Limit = 50 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.integrityUseCase.GetFlags(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *LocationIntegrityHandler) ReviewLocationFlag(c *gin.Context) {
+	flagID := c.Param("id")
+	actor := c.GetString("opsActor")
+
+	var req entities.ReviewLocationFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.integrityUseCase.ReviewFlag(flagID, actor, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
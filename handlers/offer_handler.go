@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OfferHandler struct {
+	offerUseCase *usecase.OfferUseCase
+}
+
+func NewOfferHandler(offerUseCase *usecase.OfferUseCase) *OfferHandler {
+	return &OfferHandler{
+		offerUseCase: offerUseCase,
+	}
+}
+
+func (h *OfferHandler) GetOffers(c *gin.Context) {
+	partnerID := c.GetString("partnerId")
+
+	response, err := h.offerUseCase.GetOffers(partnerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *OfferHandler) RejectOrder(c *gin.Context) {
+	deliveryID := c.Param("id")
+	partnerID := c.GetString("partnerId")
+
+	var req entities.RejectOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.offerUseCase.RejectOrder(deliveryID, partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *OfferHandler) GetMyOfferStats(c *gin.Context) {
+	partnerID := c.GetString("partnerId")
+
+	response, err := h.offerUseCase.GetOfferStats(partnerID, c.DefaultQuery("period", "week"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *OfferHandler) GetPartnerOfferStats(c *gin.Context) {
+	partnerID := c.Param("id")
+
+	response, err := h.offerUseCase.GetOfferStats(partnerID, c.DefaultQuery("period", "week"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
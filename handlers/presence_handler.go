@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PresenceHandler struct {
+	presenceUseCase *usecase.PresenceUseCase
+}
+
+func NewPresenceHandler(presenceUseCase *usecase.PresenceUseCase) *PresenceHandler {
+	return &PresenceHandler{
+		presenceUseCase: presenceUseCase,
+	}
+}
+
+func (h *PresenceHandler) Heartbeat(c *gin.Context) {
+	partnerID := c.GetString("partnerId")
+
+	response, err := h.presenceUseCase.Heartbeat(partnerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *PresenceHandler) SetBreak(c *gin.Context) {
+	partnerID := c.GetString("partnerId")
+
+	var req entities.SetBreakRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.presenceUseCase.SetBreak(partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
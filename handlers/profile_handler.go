@@ -57,27 +57,6 @@ func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-func (h *ProfileHandler) UpdateLocation(c *gin.Context) {
-	partnerID := c.GetString("partnerId")
-	
-	var req entities.UpdateLocationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   err.Error(),
-		})
-		return
-	}
-
-	response, err := h.profileUseCase.UpdateLocation(partnerID, &req)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, response)
-		return
-	}
-
-	c.JSON(http.StatusOK, response)
-}
-
 func (h *ProfileHandler) ToggleAvailability(c *gin.Context) {
 	partnerID := c.GetString("partnerId")
 	
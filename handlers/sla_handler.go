@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SLAHandler struct {
+	slaUseCase *usecase.SLAUseCase
+}
+
+func NewSLAHandler(slaUseCase *usecase.SLAUseCase) *SLAHandler {
+	return &SLAHandler{
+		slaUseCase: slaUseCase,
+	}
+}
+
+func (h *SLAHandler) GetSLAAlerts(c *gin.Context) {
+	var req entities.GetSLAAlertsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.slaUseCase.GetSLAAlerts(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StreamHandler struct {
+	streamUseCase     *usecase.StreamUseCase
+	heartbeatInterval time.Duration
+}
+
+func NewStreamHandler(streamUseCase *usecase.StreamUseCase, heartbeatInterval time.Duration) *StreamHandler {
+	return &StreamHandler{
+		streamUseCase:     streamUseCase,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// StreamTracking pushes a customer's delivery as server-sent events
+func (h *StreamHandler) StreamTracking(c *gin.Context) {
+	token := c.Param("token")
+
+	sub, snapshot, err := h.streamUseCase.SubscribeTracking(token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to load tracking",
+		})
+		return
+	}
+	if sub == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Tracking link is invalid or has expired",
+		})
+		return
+	}
+
+	h.stream(c, sub, snapshot)
+}
+
+// StreamOps pushes fleet activity as server-sent events
+func (h *StreamHandler) StreamOps(c *gin.Context) {
+	var req entities.OpsStreamRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	sub, snapshot, err := h.streamUseCase.SubscribeOps(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to open stream",
+		})
+		return
+	}
+	if sub == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Order not found",
+		})
+		return
+	}
+
+	h.stream(c, sub, snapshot)
+}
+
+func (h *StreamHandler) stream(c *gin.Context, sub *usecase.Subscription, snapshot *entities.StreamEvent) {
+	defer h.streamUseCase.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // stop nginx from buffering the stream
+	c.Status(http.StatusOK)
+
+	if snapshot != nil {
+		c.SSEvent(snapshot.Type, snapshot)
+		c.Writer.Flush()
+		if sub.Done(snapshot) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case frame, ok := <-sub.Events():
+			// Closed when we fell too far behind; the client reconnects
+			if !ok {
+				return
+			}
+			c.SSEvent(frame.Type, frame)
+			c.Writer.Flush()
+			if sub.Done(&frame) {
+				return
+			}
+		case now := <-heartbeat.C:
+			c.SSEvent("heartbeat", gin.H{"at": now})
+			c.Writer.Flush()
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrackingHandler struct {
+	trackingUseCase *usecase.TrackingUseCase
+}
+
+func NewTrackingHandler(trackingUseCase *usecase.TrackingUseCase) *TrackingHandler {
+	return &TrackingHandler{
+		trackingUseCase: trackingUseCase,
+	}
+}
+
+func (h *TrackingHandler) GetTracking(c *gin.Context) {
+	token := c.Param("token")
+
+	response, err := h.trackingUseCase.GetTracking(token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if !response.Success {
+		c.JSON(http.StatusNotFound, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TrackingHandler) IssueTrackingLink(c *gin.Context) {
+	deliveryID := c.Param("id")
+
+	response, err := h.trackingUseCase.IssueTrackingLinkByID(deliveryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
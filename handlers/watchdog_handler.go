@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WatchdogHandler struct {
+	watchdogUseCase *usecase.WatchdogUseCase
+}
+
+func NewWatchdogHandler(watchdogUseCase *usecase.WatchdogUseCase) *WatchdogHandler {
+	return &WatchdogHandler{
+		watchdogUseCase: watchdogUseCase,
+	}
+}
+
+// RunWatchdog checks for stranded orders without waiting for the next tick
+func (h *WatchdogHandler) RunWatchdog(c *gin.Context) {
+	response, err := h.watchdogUseCase.Check()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
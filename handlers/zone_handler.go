@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ZoneHandler struct {
+	zoneUseCase *usecase.ZoneUseCase
+}
+
+func NewZoneHandler(zoneUseCase *usecase.ZoneUseCase) *ZoneHandler {
+	return &ZoneHandler{
+		zoneUseCase: zoneUseCase,
+	}
+}
+
+func (h *ZoneHandler) CreateZone(c *gin.Context) {
+	var req entities.ZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.zoneUseCase.CreateZone(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ZoneHandler) UpdateZone(c *gin.Context) {
+	zoneID := c.Param("id")
+
+	var req entities.ZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.zoneUseCase.UpdateZone(zoneID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ZoneHandler) GetZone(c *gin.Context) {
+	zoneID := c.Param("id")
+
+	response, err := h.zoneUseCase.GetZone(zoneID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ZoneHandler) GetZones(c *gin.Context) {
+	var req entities.GetZonesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.zoneUseCase.GetZones(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ZoneHandler) SetPartnerHomeZone(c *gin.Context) {
+	partnerID := c.Param("id")
+
+	var req entities.SetHomeZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.zoneUseCase.SetPartnerHomeZone(partnerID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ZoneHandler) CheckServiceability(c *gin.Context) {
+	var req entities.ServiceabilityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.zoneUseCase.CheckServiceability(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,22 @@
+package callbridge
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"errors"
+)
+
+// DisabledCallBridge refuses every call. It is used in release mode when no
+// driver is configured, so calls fail rather than silently going nowhere.
+type DisabledCallBridge struct{}
+
+func NewDisabledCallBridge() *DisabledCallBridge {
+	return &DisabledCallBridge{}
+}
+
+func (b *DisabledCallBridge) Name() string {
+	return "disabled"
+}
+
+func (b *DisabledCallBridge) CreateSession(req *entities.CallBridgeRequest) (*entities.CallBridgeSession, error) {
+	return nil, errors.New("call bridge is not configured")
+}
@@ -0,0 +1,35 @@
+package callbridge
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/utils"
+	"log"
+	"time"
+)
+
+// FakeCallBridge is used for local development. It hands out a dummy proxy
+// number and logs the call, with the numbers masked, instead of placing it.
+type FakeCallBridge struct{}
+
+func NewFakeCallBridge() *FakeCallBridge {
+	return &FakeCallBridge{}
+}
+
+func (b *FakeCallBridge) Name() string {
+	return "fake"
+}
+
+func (b *FakeCallBridge) CreateSession(req *entities.CallBridgeRequest) (*entities.CallBridgeSession, error) {
+	sessionID, err := utils.RandomHex(8)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("📞 Bridging %s to %s for delivery %s (session %s)", utils.MaskPhone(req.CallerPhone), utils.MaskPhone(req.CalleePhone), req.DeliveryID, sessionID)
+
+	return &entities.CallBridgeSession{
+		SessionID:   "fake_" + sessionID,
+		ProxyNumber: "+910000000000",
+		ExpiresAt:   time.Now().Add(req.TTL),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package callbridge
+
+import (
+	"bytes"
+	"deliveryAppBackend/domain/entities"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPCallBridge talks to a masked-calling provider over HTTP. The provider
+// must accept POST <baseURL>/sessions and answer with the proxy number.
+type HTTPCallBridge struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func NewHTTPCallBridge(baseURL, apiKey string) *HTTPCallBridge {
+	return &HTTPCallBridge{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type httpSessionRequest struct {
+	Reference  string `json:"reference"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	TTLSeconds int    `json:"ttlSeconds"`
+}
+
+type httpSessionResponse struct {
+	SessionID   string    `json:"sessionId"`
+	ProxyNumber string    `json:"proxyNumber"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+func (b *HTTPCallBridge) Name() string {
+	return "http"
+}
+
+func (b *HTTPCallBridge) CreateSession(req *entities.CallBridgeRequest) (*entities.CallBridgeSession, error) {
+	body, err := json.Marshal(httpSessionRequest{
+		Reference:  req.DeliveryID,
+		From:       req.CallerPhone,
+		To:         req.CalleePhone,
+		TTLSeconds: int(req.TTL.Seconds()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, b.baseURL+"/sessions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+b.apiKey)
+
+	resp, err := b.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("call bridge responded with %d", resp.StatusCode)
+	}
+
+	var session httpSessionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, err
+	}
+	if session.ProxyNumber == "" {
+		return nil, fmt.Errorf("call bridge returned no proxy number")
+	}
+	if session.ExpiresAt.IsZero() {
+		session.ExpiresAt = time.Now().Add(req.TTL)
+	}
+
+	return &entities.CallBridgeSession{
+		SessionID:   session.SessionID,
+		ProxyNumber: session.ProxyNumber,
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
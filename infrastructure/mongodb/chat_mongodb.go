@@ -0,0 +1,125 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ChatMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewChatMongoRepository() *ChatMongoRepository {
+	r := &ChatMongoRepository{
+		collection: config.GetCollection("chat_messages"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *ChatMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "deliveryId", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create chat indexes: %v", err)
+	}
+}
+
+func (r *ChatMongoRepository) Create(message *entities.ChatMessage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	message.CreatedAt = time.Now()
+	if message.ReadBy == nil {
+		message.ReadBy = []entities.MessageReceipt{}
+	}
+
+	result, err := r.collection.InsertOne(ctx, message)
+	if err != nil {
+		return err
+	}
+
+	message.MessageID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (r *ChatMongoRepository) GetMessages(deliveryID, afterID string, limit int) ([]entities.ChatMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"deliveryId": deliveryID}
+	if afterID != "" {
+		objectID, err := primitive.ObjectIDFromHex(afterID)
+		if err != nil {
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$gt": objectID}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []entities.ChatMessage
+	if err = cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *ChatMongoRepository) MarkRead(deliveryID, readerRole string, readAt time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$push": bson.M{
+			"readBy": entities.MessageReceipt{Role: readerRole, ReadAt: readAt},
+		},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, unreadFilter(deliveryID, readerRole), update)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.ModifiedCount), nil
+}
+
+func (r *ChatMongoRepository) CountUnread(deliveryID, readerRole string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, unreadFilter(deliveryID, readerRole))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func unreadFilter(deliveryID, readerRole string) bson.M {
+	return bson.M{
+		"deliveryId":  deliveryID,
+		"senderRole":  bson.M{"$ne": readerRole},
+		"readBy.role": bson.M{"$ne": readerRole},
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"deliveryAppBackend/config"
 	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
 	"errors"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,9 +20,68 @@ type DeliveryMongoRepository struct {
 }
 
 func NewDeliveryMongoRepository() *DeliveryMongoRepository {
-	return &DeliveryMongoRepository{
+	r := &DeliveryMongoRepository{
 		collection: config.GetCollection("deliveries"),
 	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *DeliveryMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "trackingToken", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{Keys: bson.D{{Key: "pickupLocation", Value: "2dsphere"}}},
+		{Keys: bson.D{{Key: "deliveryLocation", Value: "2dsphere"}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create delivery indexes: %v", err)
+	}
+
+	// One delivery per order, even when the order service retries a create.
+	// Built on its own so duplicates already stored can't hold up the others.
+	_, err = r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "orderId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create the unique orderId index: %v", err)
+		r.logDuplicateOrders(ctx)
+	}
+}
+
+// logDuplicateOrders names the orders with more than one delivery, which ops
+// have to resolve before the unique orderId index can be built
+func (r *DeliveryMongoRepository) logDuplicateOrders(ctx context.Context) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$orderId", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$limit", Value: 50}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("❌ Failed to look for duplicate orders: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		OrderID string `bson:"_id"`
+		Count   int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		log.Printf("❌ Failed to look for duplicate orders: %v", err)
+		return
+	}
+	for _, row := range rows {
+		log.Printf("⚠️  Order %s has %d deliveries; keep one and cancel or remove the rest", row.OrderID, row.Count)
+	}
 }
 
 func (r *DeliveryMongoRepository) GetActiveOrdersByPartner(partnerID string) ([]entities.Delivery, error) {
@@ -29,7 +90,7 @@ func (r *DeliveryMongoRepository) GetActiveOrdersByPartner(partnerID string) ([]
 
 	filter := bson.M{
 		"partnerId": partnerID,
-		"status":    bson.M{"$in": []string{"pending", "picked_up", "in_transit"}},
+		"status":    bson.M{"$in": []string{"pending", "assigned", "picked_up", "in_transit"}},
 	}
 
 	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}))
@@ -87,14 +148,14 @@ func (r *DeliveryMongoRepository) GetByID(deliveryID string) (*entities.Delivery
 
 	objectID, err := primitive.ObjectIDFromHex(deliveryID)
 	if err != nil {
-		return nil, err
+		return nil, repositories.ErrDeliveryNotFound
 	}
 
 	var delivery entities.Delivery
 	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&delivery)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("delivery not found")
+			return nil, repositories.ErrDeliveryNotFound
 		}
 		return nil, err
 	}
@@ -124,8 +185,12 @@ func (r *DeliveryMongoRepository) Create(delivery *entities.Delivery) error {
 
 	delivery.CreatedAt = time.Now()
 	delivery.UpdatedAt = time.Now()
+	setDeliveryGeoPoints(delivery)
 
 	result, err := r.collection.InsertOne(ctx, delivery)
+	if mongo.IsDuplicateKeyError(err) {
+		return repositories.ErrDeliveryExists
+	}
 	if err != nil {
 		return err
 	}
@@ -144,35 +209,211 @@ func (r *DeliveryMongoRepository) Update(delivery *entities.Delivery) error {
 	}
 
 	delivery.UpdatedAt = time.Now()
+	setDeliveryGeoPoints(delivery)
 
 	_, err = r.collection.ReplaceOne(ctx, bson.M{"_id": objectID}, delivery)
 	return err
 }
 
-func (r *DeliveryMongoRepository) AcceptOrder(deliveryID, partnerID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// AcceptOrder assigns a pending order to the partner. capacity, when set, is
+// checked against the partner's batch in the same transaction. tracking, when
+// set, is the customer's tracking link and is only saved if the order is taken.
+func (r *DeliveryMongoRepository) AcceptOrder(deliveryID, partnerID string, capacity *entities.CapacityCheck, tracking *entities.TrackingLinkResponse, entry *entities.TimelineEntry, event *entities.DeliveryEvent) error {
+	objectID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entry.At = now
+	set := bson.M{
+		"partnerId":  partnerID,
+		"status":     "assigned",
+		"assignedAt": now,
+		"updatedAt":  now,
+	}
+	if tracking != nil {
+		set["trackingToken"] = tracking.Token
+		set["trackingExpiresAt"] = tracking.ExpiresAt
+	}
+	update := bson.M{
+		"$set": set,
+		"$push": bson.M{
+			"timeline": entry,
+		},
+	}
+
+	return withTransaction(func(sc mongo.SessionContext) error {
+		if capacity != nil {
+			if err := r.checkCapacity(sc, objectID, partnerID, capacity, now); err != nil {
+				return err
+			}
+		}
+
+		result, err := r.collection.UpdateOne(sc, bson.M{"_id": objectID, "status": "pending"}, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return errors.New("order is no longer pending")
+		}
+		return insertOutboxEvent(sc, event)
+	})
+}
+
+// checkCapacity fails unless the order fits on top of what the partner
+// already carries. It writes to the partner first, so two assignments to the
+// same partner conflict and the retried one sees the other's order.
+func (r *DeliveryMongoRepository) checkCapacity(sc mongo.SessionContext, deliveryID primitive.ObjectID, partnerID string, capacity *entities.CapacityCheck, now time.Time) error {
+	partnerObjectID, err := primitive.ObjectIDFromHex(partnerID)
+	if err != nil {
+		return err
+	}
+
+	result, err := config.GetCollection("delivery_partners").UpdateOne(sc,
+		bson.M{"_id": partnerObjectID},
+		bson.M{"$set": bson.M{"lastAssignedAt": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrPartnerNotFound
+	}
+
+	// Orders only offered to the partner don't take up room yet. Orders from
+	// before loads were recorded count as empty; the usecase's check derives them.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"_id":       bson.M{"$ne": deliveryID},
+			"partnerId": partnerID,
+			"status":    bson.M{"$in": []string{"assigned", "picked_up", "in_transit"}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          nil,
+			"weightKg":     bson.M{"$sum": "$weightKg"},
+			"volumeLitres": bson.M{"$sum": "$volumeLitres"},
+		}}},
+	}
 
+	cursor, err := r.collection.Aggregate(sc, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(sc)
+
+	var rows []struct {
+		WeightKg     float64 `bson:"weightKg"`
+		VolumeLitres float64 `bson:"volumeLitres"`
+	}
+	if err = cursor.All(sc, &rows); err != nil {
+		return err
+	}
+
+	weight, volume := capacity.WeightKg, capacity.VolumeLitres
+	if len(rows) > 0 {
+		weight += rows[0].WeightKg
+		volume += rows[0].VolumeLitres
+	}
+	if weight > capacity.MaxWeightKg || volume > capacity.MaxVolumeLitres {
+		return repositories.ErrCapacityExceeded
+	}
+	return nil
+}
+
+func (r *DeliveryMongoRepository) ReleaseOrder(deliveryID, partnerID string, entry *entities.TimelineEntry, event *entities.DeliveryEvent) error {
 	objectID, err := primitive.ObjectIDFromHex(deliveryID)
 	if err != nil {
 		return err
 	}
 
 	now := time.Now()
+	entry.At = now
+	filter := bson.M{
+		"_id":       objectID,
+		"partnerId": partnerID,
+		"status":    bson.M{"$in": []string{"pending", "assigned"}},
+	}
 	update := bson.M{
 		"$set": bson.M{
-			"partnerId":  partnerID,
-			"status":     "picked_up",
-			"pickedUpAt": now,
-			"updatedAt":  now,
+			"partnerId": "",
+			"status":    "pending",
+			"updatedAt": now,
+		},
+		// Scans made by the previous partner don't count for the next one
+		"$unset": bson.M{
+			"pickupVerification": "",
+		},
+		"$push": bson.M{
+			"timeline": entry,
 		},
 	}
 
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
-	return err
+	return withTransaction(func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return errors.New("order is no longer held by the partner")
+		}
+		return insertOutboxEvent(sc, event)
+	})
+}
+
+func (r *DeliveryMongoRepository) ReassignOrder(deliveryID, fromPartnerID, toPartnerID string, capacity *entities.CapacityCheck, tracking *entities.TrackingLinkResponse, entry *entities.TimelineEntry, event *entities.DeliveryEvent) error {
+	objectID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entry.At = now
+	filter := bson.M{
+		"_id":       objectID,
+		"partnerId": fromPartnerID,
+		"status":    bson.M{"$in": []string{"pending", "assigned"}},
+	}
+	set := bson.M{
+		"partnerId":  toPartnerID,
+		"status":     "assigned",
+		"assignedAt": now,
+		"updatedAt":  now,
+	}
+	if tracking != nil {
+		set["trackingToken"] = tracking.Token
+		set["trackingExpiresAt"] = tracking.ExpiresAt
+	}
+	update := bson.M{
+		"$set": set,
+		// Scans made by the previous partner don't count for the next one
+		"$unset": bson.M{
+			"pickupVerification": "",
+		},
+		"$push": bson.M{
+			"timeline": entry,
+		},
+	}
+
+	return withTransaction(func(sc mongo.SessionContext) error {
+		if capacity != nil {
+			if err := r.checkCapacity(sc, objectID, toPartnerID, capacity, now); err != nil {
+				return err
+			}
+		}
+
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return errors.New("order is no longer held by the partner")
+		}
+		return insertOutboxEvent(sc, event)
+	})
 }
 
-func (r *DeliveryMongoRepository) UpdateStatus(deliveryID, status string) error {
+func (r *DeliveryMongoRepository) AddTimelineEntry(deliveryID string, entry *entities.TimelineEntry) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -181,6 +422,20 @@ func (r *DeliveryMongoRepository) UpdateStatus(deliveryID, status string) error
 		return err
 	}
 
+	entry.At = time.Now()
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{
+		"$push": bson.M{"timeline": entry},
+		"$set":  bson.M{"updatedAt": entry.At},
+	})
+	return err
+}
+
+func (r *DeliveryMongoRepository) UpdateStatus(deliveryID, partnerID, fromStatus, status string, event *entities.DeliveryEvent) error {
+	objectID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 	update := bson.M{
 		"$set": bson.M{
@@ -194,14 +449,23 @@ func (r *DeliveryMongoRepository) UpdateStatus(deliveryID, status string) error
 		update["$set"].(bson.M)["inTransitAt"] = now
 	}
 
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
-	return err
+	return withTransaction(func(sc mongo.SessionContext) error {
+		filter := bson.M{"_id": objectID, "status": fromStatus, "partnerId": partnerID}
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return errors.New("order is no longer " + fromStatus + " with the partner")
+		}
+		return insertOutboxEvent(sc, event)
+	})
 }
 
-func (r *DeliveryMongoRepository) CompleteDelivery(deliveryID string, notes string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
+// CompleteDelivery marks the delivery delivered, records the partner's earnings
+// and queues the event in one transaction, so a delivered order is never unpaid.
+// slaStatus, when set, is the final met or breached outcome.
+func (r *DeliveryMongoRepository) CompleteDelivery(deliveryID string, notes string, slaStatus string, earnings *entities.Earnings, event *entities.DeliveryEvent) error {
 	objectID, err := primitive.ObjectIDFromHex(deliveryID)
 	if err != nil {
 		return err
@@ -216,9 +480,95 @@ func (r *DeliveryMongoRepository) CompleteDelivery(deliveryID string, notes stri
 			"updatedAt":   now,
 		},
 	}
+	if slaStatus != "" {
+		update["$set"].(bson.M)["slaStatus"] = slaStatus
+	}
 
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
-	return err
+	return withTransaction(func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, bson.M{"_id": objectID, "status": "in_transit"}, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return errors.New("order is not in transit")
+		}
+
+		earnings.CreatedAt = now
+		inserted, err := config.GetCollection("earnings").InsertOne(sc, earnings)
+		if err != nil {
+			return err
+		}
+		earnings.EarningsID = inserted.InsertedID.(primitive.ObjectID).Hex()
+
+		return insertOutboxEvent(sc, event)
+	})
+}
+
+func (r *DeliveryMongoRepository) RecordPickupScan(deliveryID string, verification *entities.PickupVerification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"pickupVerification": verification,
+			"updatedAt":          time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "status": "assigned"}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("order is not awaiting pickup")
+	}
+	return nil
+}
+
+func (r *DeliveryMongoRepository) ConfirmPickup(deliveryID, confirmedBy, note string, override bool, event *entities.DeliveryEvent) error {
+	objectID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":                              "picked_up",
+			"pickedUpAt":                          now,
+			"pickupVerification.confirmedBy":      confirmedBy,
+			"pickupVerification.confirmedAt":      now,
+			"pickupVerification.confirmationNote": note,
+			"updatedAt":                           now,
+		},
+	}
+
+	// Only an assigned order whose last scan matched can be handed over,
+	// unless the warehouse overrides a mismatched scan
+	filter := bson.M{
+		"_id":                          objectID,
+		"status":                       "assigned",
+		"pickupVerification.isMatched": true,
+	}
+	if override {
+		filter["pickupVerification.isMatched"] = bson.M{"$exists": true}
+	}
+
+	return withTransaction(func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return errors.New("order is not awaiting pickup confirmation")
+		}
+		return insertOutboxEvent(sc, event)
+	})
 }
 
 func (r *DeliveryMongoRepository) AssignToPartner(orderID, partnerID string) error {
@@ -233,16 +583,28 @@ func (r *DeliveryMongoRepository) AssignToPartner(orderID, partnerID string) err
 		},
 	}
 
-	_, err := r.collection.UpdateOne(ctx, bson.M{"orderId": orderID}, update)
-	return err
+	// Only pending orders can be handed to a partner; anything else was accepted or closed meanwhile
+	result, err := r.collection.UpdateOne(ctx, bson.M{"orderId": orderID, "status": "pending"}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("order is no longer pending")
+	}
+	return nil
 }
 
-func (r *DeliveryMongoRepository) GetPendingOrders() ([]entities.Delivery, error) {
+func (r *DeliveryMongoRepository) GetPendingOrders(windowOpensBefore time.Time) ([]entities.Delivery, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	filter := bson.M{
 		"status": "pending",
+		"$or": []bson.M{
+			{"windowStart": bson.M{"$exists": false}},
+			{"windowStart": nil},
+			{"windowStart": bson.M{"$lte": windowOpensBefore}},
+		},
 	}
 
 	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
@@ -259,6 +621,300 @@ func (r *DeliveryMongoRepository) GetPendingOrders() ([]entities.Delivery, error
 	return deliveries, nil
 }
 
+func (r *DeliveryMongoRepository) FindPendingOrdersNear(point entities.GeoPoint, radiusKm float64, limit int) ([]entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":    "pending",
+		"partnerId": bson.M{"$in": []interface{}{"", nil}},
+		"pickupLocation": bson.M{
+			"$nearSphere": bson.M{
+				"$geometry":    point,
+				"$maxDistance": radiusKm * 1000,
+			},
+		},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []entities.Delivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *DeliveryMongoRepository) CountActiveOrdersByPartners(partnerIDs []string) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"partnerId": bson.M{"$in": partnerIDs},
+			"status":    bson.M{"$in": []string{"pending", "assigned", "picked_up", "in_transit"}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$partnerId",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		PartnerID string `bson:"_id"`
+		Count     int    `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.PartnerID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *DeliveryMongoRepository) FindPartnersHoldingOrders() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	values, err := r.collection.Distinct(ctx, "partnerId", bson.M{
+		"partnerId": bson.M{"$ne": ""},
+		"status":    bson.M{"$in": []string{"assigned", "picked_up", "in_transit"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	partnerIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		if partnerID, ok := value.(string); ok {
+			partnerIDs = append(partnerIDs, partnerID)
+		}
+	}
+	return partnerIDs, nil
+}
+
+func (r *DeliveryMongoRepository) GetActiveOrdersByPartners(partnerIDs []string) (map[string][]entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"partnerId": bson.M{"$in": partnerIDs},
+		"status":    bson.M{"$in": []string{"pending", "assigned", "picked_up", "in_transit"}},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []entities.Delivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	active := make(map[string][]entities.Delivery)
+	for _, d := range deliveries {
+		active[d.PartnerID] = append(active[d.PartnerID], d)
+	}
+	return active, nil
+}
+
+func (r *DeliveryMongoRepository) SetTrackingToken(deliveryID, token string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"trackingToken":     token,
+			"trackingExpiresAt": expiresAt,
+			"updatedAt":         time.Now(),
+		},
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *DeliveryMongoRepository) GetByTrackingToken(token string) (*entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var delivery entities.Delivery
+	err := r.collection.FindOne(ctx, bson.M{"trackingToken": token}).Decode(&delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (r *DeliveryMongoRepository) AddCallAttempt(deliveryID string, attempt *entities.CallAttempt) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$push": bson.M{"callAttempts": attempt},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+// GetInProgressDeliveries returns every delivery that has not been delivered or cancelled
+func (r *DeliveryMongoRepository) GetInProgressDeliveries() ([]entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status": bson.M{"$in": []string{"pending", "assigned", "picked_up", "in_transit"}},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []entities.Delivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *DeliveryMongoRepository) UpdateSLA(deliveryID string, update *entities.DeliverySLAUpdate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	set := bson.M{
+		"slaStatus": update.SLAStatus,
+		"updatedAt": now,
+	}
+	if update.SLADeadline != nil {
+		set["slaDeadline"] = update.SLADeadline
+	}
+	if update.SLABreachedAt != nil {
+		set["slaBreachedAt"] = update.SLABreachedAt
+	}
+	if update.ETA != nil {
+		set["eta"] = update.ETA
+		set["etaUpdatedAt"] = now
+		set["remainingDistance"] = update.RemainingDistance
+	}
+	if update.Route != nil {
+		set["distance"] = update.Route.DistanceKm
+		set["duration"] = update.Route.DurationSeconds
+		set["routeSource"] = update.Route.Source
+	}
+
+	// The checker works from a snapshot; an order completed since then keeps
+	// the final status CompleteDelivery wrote
+	filter := bson.M{
+		"_id":       objectID,
+		"status":    bson.M{"$in": []string{"pending", "assigned", "picked_up", "in_transit"}},
+		"slaStatus": bson.M{"$ne": "met"},
+	}
+
+	_, err = r.collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	return err
+}
+
+func (r *DeliveryMongoRepository) GetDeliveriesBySLAStatus(slaStatuses []string, warehouseID string) ([]entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":    bson.M{"$in": []string{"pending", "assigned", "picked_up", "in_transit"}},
+		"slaStatus": bson.M{"$in": slaStatuses},
+	}
+	if warehouseID != "" {
+		filter["warehouseId"] = warehouseID
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "slaDeadline", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []entities.Delivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveredWithoutEarnings finds delivered orders that have no earnings record
+func (r *DeliveryMongoRepository) GetDeliveredWithoutEarnings(limit int) ([]entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": "delivered"}}},
+		{{Key: "$addFields", Value: bson.M{"deliveryIdStr": bson.M{"$toString": "$_id"}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "earnings",
+			"localField":   "deliveryIdStr",
+			"foreignField": "deliveryId",
+			"as":           "earnings",
+		}}},
+		{{Key: "$match", Value: bson.M{"earnings": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"earnings": 0, "deliveryIdStr": 0}}},
+		{{Key: "$sort", Value: bson.M{"deliveredAt": 1}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []entities.Delivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
 func (r *DeliveryMongoRepository) GetDeliveriesCountByPartner(partnerID string, period string) (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -289,3 +945,134 @@ func (r *DeliveryMongoRepository) GetDeliveriesCountByPartner(partnerID string,
 	return int(count), err
 }
 
+func (r *DeliveryMongoRepository) GetSlotAdherence(groupBy, period, partnerID, warehouseID string) ([]entities.SlotAdherence, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var startDate time.Time
+	now := time.Now()
+
+	switch period {
+	case "today":
+		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	case "week":
+		startDate = now.AddDate(0, 0, -7)
+	case "month":
+		startDate = now.AddDate(0, -1, 0)
+	default:
+		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+
+	match := bson.M{
+		"status":      "delivered",
+		"windowEnd":   bson.M{"$ne": nil},
+		"deliveredAt": bson.M{"$gte": startDate},
+	}
+	if partnerID != "" {
+		match["partnerId"] = partnerID
+	}
+	if warehouseID != "" {
+		match["warehouseId"] = warehouseID
+	}
+
+	groupKey := "$partnerId"
+	if groupBy == "warehouse" {
+		groupKey = "$warehouseId"
+	}
+
+	isLate := bson.M{"$gt": bson.A{"$deliveredAt", "$windowEnd"}}
+	minutesLate := bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{"$deliveredAt", "$windowEnd"}}, 60000}}
+
+	// Completion is held until the window opens, so a delivery is either on time or late
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: groupKey},
+			{Key: "total", Value: bson.M{"$sum": 1}},
+			{Key: "late", Value: bson.M{"$sum": bson.M{"$cond": bson.A{isLate, 1, 0}}}},
+			{Key: "avgMinutesLate", Value: bson.M{"$avg": bson.M{"$cond": bson.A{isLate, minutesLate, nil}}}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"onTime":         bson.M{"$subtract": bson.A{"$total", "$late"}},
+			"avgMinutesLate": bson.M{"$ifNull": bson.A{"$avgMinutesLate", 0}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"total": -1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []entities.SlotAdherence
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// setDeliveryGeoPoints keeps the GeoJSON points in step with the float coordinates
+func setDeliveryGeoPoints(delivery *entities.Delivery) {
+	if delivery.PickupLatitude != 0 || delivery.PickupLongitude != 0 {
+		delivery.PickupLocation = entities.NewGeoPoint(delivery.PickupLatitude, delivery.PickupLongitude)
+	}
+	if delivery.DeliveryLatitude != 0 || delivery.DeliveryLongitude != 0 {
+		delivery.DeliveryLocation = entities.NewGeoPoint(delivery.DeliveryLatitude, delivery.DeliveryLongitude)
+	}
+}
+
+func (r *DeliveryMongoRepository) FindDeliveries(f *repositories.DeliveryFilter, limit, offset int) ([]entities.Delivery, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if len(f.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": f.Statuses}
+	}
+	if f.Unassigned {
+		filter["partnerId"] = bson.M{"$in": []interface{}{"", nil}}
+	}
+	if len(f.SLAStatuses) > 0 {
+		filter["slaStatus"] = bson.M{"$in": f.SLAStatuses}
+	}
+	if f.WarehouseID != "" {
+		filter["warehouseId"] = f.WarehouseID
+	}
+	if f.Zones != nil {
+		warehouseIDs := make([]string, 0)
+		or := make([]bson.M, 0, len(f.Zones)+1)
+		for _, z := range f.Zones {
+			warehouseIDs = append(warehouseIDs, z.WarehouseIDs...)
+			or = append(or, bson.M{"pickupLocation": bson.M{"$geoWithin": bson.M{"$geometry": z.Area}}})
+		}
+		or = append(or, bson.M{"warehouseId": bson.M{"$in": warehouseIDs}})
+		filter["$or"] = or
+	}
+
+	// Get total count
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results, oldest first so nothing waits forever
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []entities.Delivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, int(total), nil
+}
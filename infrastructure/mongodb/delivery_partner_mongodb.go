@@ -4,22 +4,45 @@ import (
 	"context"
 	"deliveryAppBackend/config"
 	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
 	"errors"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// partnerPublicProjection leaves out credentials and KYC data in bulk reads
+var partnerPublicProjection = bson.M{
+	"otp": 0, "pin": 0, "aadharNumber": 0, "panNumber": 0, "bankAccountNumber": 0, "ifsc": 0,
+}
+
 type DeliveryPartnerMongoRepository struct {
 	collection *mongo.Collection
 }
 
 func NewDeliveryPartnerMongoRepository() *DeliveryPartnerMongoRepository {
-	return &DeliveryPartnerMongoRepository{
+	r := &DeliveryPartnerMongoRepository{
 		collection: config.GetCollection("delivery_partners"),
 	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *DeliveryPartnerMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+		{Keys: bson.D{{Key: "isAvailable", Value: 1}, {Key: "lastHeartbeatAt", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create delivery partner indexes: %v", err)
+	}
 }
 
 func (r *DeliveryPartnerMongoRepository) FindByPhoneNumber(phoneNumber string) (*entities.DeliveryPartner, error) {
@@ -59,14 +82,14 @@ func (r *DeliveryPartnerMongoRepository) FindByID(partnerID string) (*entities.D
 
 	objectID, err := primitive.ObjectIDFromHex(partnerID)
 	if err != nil {
-		return nil, err
+		return nil, repositories.ErrPartnerNotFound
 	}
 
 	var result bson.M
 	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&result)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("partner not found")
+			return nil, repositories.ErrPartnerNotFound
 		}
 		return nil, err
 	}
@@ -83,6 +106,42 @@ func (r *DeliveryPartnerMongoRepository) FindByID(partnerID string) (*entities.D
 	return &partner, nil
 }
 
+func (r *DeliveryPartnerMongoRepository) FindByIDs(partnerIDs []string) (map[string]*entities.DeliveryPartner, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objectIDs := make([]primitive.ObjectID, 0, len(partnerIDs))
+	for _, id := range partnerIDs {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			continue
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	partners := make(map[string]*entities.DeliveryPartner, len(objectIDs))
+	if len(objectIDs) == 0 {
+		return partners, nil
+	}
+
+	opts := options.Find().SetProjection(partnerPublicProjection)
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []entities.DeliveryPartner
+	if err = cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	for i := range found {
+		partners[found[i].PartnerID] = &found[i]
+	}
+	return partners, nil
+}
+
 func (r *DeliveryPartnerMongoRepository) Create(partner *entities.DeliveryPartner) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -208,7 +267,9 @@ func (r *DeliveryPartnerMongoRepository) UpdateLocation(partnerID string, latitu
 		"$set": bson.M{
 			"currentLatitude":  latitude,
 			"currentLongitude": longitude,
+			"location":         entities.NewGeoPoint(latitude, longitude),
 			"lastLocationAt":   time.Now(),
+			"lastHeartbeatAt":  time.Now(),
 			"updatedAt":        time.Now(),
 		},
 	}
@@ -217,6 +278,41 @@ func (r *DeliveryPartnerMongoRepository) UpdateLocation(partnerID string, latitu
 	return err
 }
 
+func (r *DeliveryPartnerMongoRepository) UpdateLocationIfNewer(partnerID string, latitude, longitude float64, recordedAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(partnerID)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id": objectID,
+		"$or": []bson.M{
+			{"lastLocationAt": bson.M{"$lt": recordedAt}},
+			{"lastLocationAt": bson.M{"$exists": false}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"currentLatitude":  latitude,
+			"currentLongitude": longitude,
+			"location":         entities.NewGeoPoint(latitude, longitude),
+			"lastLocationAt":   recordedAt,
+			"lastHeartbeatAt":  time.Now(),
+			"updatedAt":        time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
 func (r *DeliveryPartnerMongoRepository) ToggleAvailability(partnerID string, isAvailable bool) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -226,15 +322,230 @@ func (r *DeliveryPartnerMongoRepository) ToggleAvailability(partnerID string, is
 		return err
 	}
 
+	set := bson.M{
+		"isAvailable": isAvailable,
+		"presence":    entities.PresenceOffline,
+		"updatedAt":   time.Now(),
+	}
+	// Going online counts as a heartbeat, so the sweeper doesn't take them straight back off
+	if isAvailable {
+		set["presence"] = entities.PresenceOnline
+		set["lastHeartbeatAt"] = time.Now()
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": set})
+	return err
+}
+
+func (r *DeliveryPartnerMongoRepository) Heartbeat(partnerID string) (*entities.DeliveryPartner, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(partnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	// A pipeline update so a stale partner comes back online in the same write
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"lastHeartbeatAt": now,
+			"updatedAt":       now,
+			"presence": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$presence", entities.PresenceStale}},
+				entities.PresenceOnline,
+				"$presence",
+			}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().
+		SetProjection(partnerPublicProjection).
+		SetReturnDocument(options.After)
+
+	var partner entities.DeliveryPartner
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&partner)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrPartnerNotFound
+		}
+		return nil, err
+	}
+
+	return &partner, nil
+}
+
+func (r *DeliveryPartnerMongoRepository) SetBreak(partnerID string, onBreak bool) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(partnerID)
+	if err != nil {
+		return false, err
+	}
+
+	presence := entities.PresenceOnline
+	if onBreak {
+		presence = entities.PresenceOnBreak
+	}
 	update := bson.M{
 		"$set": bson.M{
-			"isAvailable": isAvailable,
-			"updatedAt":   time.Now(),
+			"presence":        presence,
+			"lastHeartbeatAt": time.Now(),
+			"updatedAt":       time.Now(),
 		},
 	}
 
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
-	return err
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "isAvailable": true}, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+func (r *DeliveryPartnerMongoRepository) SweepPresence(staleBefore, offlineBefore time.Time, holdingOrders []string) (*entities.PresenceSweep, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	sweep := &entities.PresenceSweep{}
+
+	keep := make([]primitive.ObjectID, 0, len(holdingOrders))
+	for _, partnerID := range holdingOrders {
+		if objectID, err := primitive.ObjectIDFromHex(partnerID); err == nil {
+			keep = append(keep, objectID)
+		}
+	}
+
+	// Offline first, so the stale pass below doesn't touch the same partners.
+	// A partner without a heartbeat yet hasn't been seen by the migration and
+	// is left alone.
+	result, err := r.collection.UpdateMany(ctx, bson.M{
+		"_id":             bson.M{"$nin": keep},
+		"isAvailable":     true,
+		"lastHeartbeatAt": bson.M{"$lt": offlineBefore},
+	}, bson.M{"$set": bson.M{
+		"isAvailable": false,
+		"presence":    entities.PresenceOffline,
+		"updatedAt":   now,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	sweep.Offline = result.ModifiedCount
+
+	result, err = r.collection.UpdateMany(ctx, bson.M{
+		"isAvailable":     true,
+		"presence":        bson.M{"$nin": []string{entities.PresenceStale, entities.PresenceOnBreak}},
+		"lastHeartbeatAt": bson.M{"$lt": staleBefore},
+	}, bson.M{"$set": bson.M{
+		"presence":  entities.PresenceStale,
+		"updatedAt": now,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	sweep.Stale = result.ModifiedCount
+
+	// Location pings count as heartbeats but don't revive a stale partner themselves
+	result, err = r.collection.UpdateMany(ctx, bson.M{
+		"isAvailable":     true,
+		"presence":        entities.PresenceStale,
+		"lastHeartbeatAt": bson.M{"$gte": staleBefore},
+	}, bson.M{"$set": bson.M{
+		"presence":  entities.PresenceOnline,
+		"updatedAt": now,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	sweep.Online = result.ModifiedCount
+
+	return sweep, nil
+}
+
+func (r *DeliveryPartnerMongoRepository) FindAvailablePartnersNear(point entities.GeoPoint, radiusKm float64, locatedSince time.Time, limit int) ([]entities.DeliveryPartner, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"isAvailable": true,
+		"isVerified":  true,
+		"presence":    bson.M{"$nin": []string{entities.PresenceOnBreak, entities.PresenceStale}},
+		// Filtered here rather than after the limit, so stale partners close
+		// to the point don't crowd out fresh ones further away
+		"lastLocationAt": bson.M{"$gte": locatedSince},
+		"location": bson.M{
+			"$nearSphere": bson.M{
+				"$geometry":    point,
+				"$maxDistance": radiusKm * 1000,
+			},
+		},
+	}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetProjection(partnerPublicProjection)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var partners []entities.DeliveryPartner
+	if err = cursor.All(ctx, &partners); err != nil {
+		return nil, err
+	}
+
+	return partners, nil
+}
+
+func (r *DeliveryPartnerMongoRepository) FindPartners(f *repositories.PartnerFilter, limit, offset int) ([]entities.DeliveryPartner, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if f.IsAvailable != nil {
+		filter["isAvailable"] = *f.IsAvailable
+	}
+	if f.Zones != nil {
+		// Partners based in one of the zones or currently inside one
+		zoneIDs := make([]string, 0, len(f.Zones))
+		or := make([]bson.M, 0, len(f.Zones)+1)
+		for _, z := range f.Zones {
+			zoneIDs = append(zoneIDs, z.ZoneID)
+			or = append(or, bson.M{"location": bson.M{"$geoWithin": bson.M{"$geometry": z.Area}}})
+		}
+		or = append(or, bson.M{"homeZoneId": bson.M{"$in": zoneIDs}})
+		filter["$or"] = or
+	}
+
+	// Get total count
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetProjection(partnerPublicProjection)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var partners []entities.DeliveryPartner
+	if err = cursor.All(ctx, &partners); err != nil {
+		return nil, 0, err
+	}
+
+	return partners, int(total), nil
 }
 
 func (r *DeliveryPartnerMongoRepository) GetTotalDeliveries(partnerID string) (int, error) {
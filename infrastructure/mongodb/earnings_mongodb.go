@@ -4,6 +4,7 @@ import (
 	"context"
 	"deliveryAppBackend/config"
 	"deliveryAppBackend/domain/entities"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -17,9 +18,25 @@ type EarningsMongoRepository struct {
 }
 
 func NewEarningsMongoRepository() *EarningsMongoRepository {
-	return &EarningsMongoRepository{
+	r := &EarningsMongoRepository{
 		collection: config.GetCollection("earnings"),
 	}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes makes sure a delivery can only ever be paid once
+func (r *EarningsMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "deliveryId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create earnings indexes: %v", err)
+	}
 }
 
 func (r *EarningsMongoRepository) Create(earnings *entities.Earnings) error {
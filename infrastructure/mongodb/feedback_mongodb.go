@@ -0,0 +1,197 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FeedbackMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFeedbackMongoRepository() *FeedbackMongoRepository {
+	r := &FeedbackMongoRepository{
+		collection: config.GetCollection("feedback"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes allows a single feedback per delivery
+func (r *FeedbackMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "deliveryId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "partnerId", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create feedback indexes: %v", err)
+	}
+}
+
+func (r *FeedbackMongoRepository) Create(feedback *entities.Feedback) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	feedback.CreatedAt = time.Now()
+	feedback.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, feedback)
+	if mongo.IsDuplicateKeyError(err) {
+		return repositories.ErrFeedbackExists
+	}
+	if err != nil {
+		return err
+	}
+
+	feedback.FeedbackID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (r *FeedbackMongoRepository) GetByID(feedbackID string) (*entities.Feedback, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(feedbackID)
+	if err != nil {
+		return nil, err
+	}
+
+	var feedback entities.Feedback
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&feedback)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("feedback not found")
+		}
+		return nil, err
+	}
+
+	return &feedback, nil
+}
+
+func (r *FeedbackMongoRepository) GetByDeliveryID(deliveryID string) (*entities.Feedback, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var feedback entities.Feedback
+	err := r.collection.FindOne(ctx, bson.M{"deliveryId": deliveryID}).Decode(&feedback)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &feedback, nil
+}
+
+func (r *FeedbackMongoRepository) GetByPartner(partnerID string, limit, offset int) ([]entities.Feedback, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"partnerId": partnerID}
+
+	// Get total count
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var feedback []entities.Feedback
+	if err = cursor.All(ctx, &feedback); err != nil {
+		return nil, 0, err
+	}
+
+	return feedback, int(total), nil
+}
+
+func (r *FeedbackMongoRepository) GetRatedFeedback(partnerID string) ([]entities.Feedback, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"partnerId":  partnerID,
+		"isExcluded": false,
+	}
+	opts := options.Find().SetProjection(bson.M{"rating": 1, "createdAt": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var feedback []entities.Feedback
+	if err = cursor.All(ctx, &feedback); err != nil {
+		return nil, err
+	}
+
+	return feedback, nil
+}
+
+func (r *FeedbackMongoRepository) SetExcluded(feedbackID string, excluded bool, actor, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(feedbackID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var update bson.M
+	if excluded {
+		update = bson.M{
+			"$set": bson.M{
+				"isExcluded":     true,
+				"excludedBy":     actor,
+				"excludedReason": reason,
+				"excludedAt":     now,
+				"updatedAt":      now,
+			},
+		}
+	} else {
+		update = bson.M{
+			"$set":   bson.M{"isExcluded": false, "updatedAt": now},
+			"$unset": bson.M{"excludedBy": "", "excludedReason": "", "excludedAt": ""},
+		}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("feedback not found")
+	}
+	return nil
+}
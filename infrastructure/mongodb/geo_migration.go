@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MigrateGeoLocations backfills the GeoJSON points used by the 2dsphere
+// indexes from the float coordinates on documents written before they existed.
+// Documents that already have a point, or have no usable coordinates, are left
+// alone, so it is safe to run on every start.
+func MigrateGeoLocations() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	migrations := []struct {
+		collection string
+		field      string
+		latField   string
+		lngField   string
+	}{
+		{"delivery_partners", "location", "currentLatitude", "currentLongitude"},
+		{"deliveries", "pickupLocation", "pickupLatitude", "pickupLongitude"},
+		{"deliveries", "deliveryLocation", "deliveryLatitude", "deliveryLongitude"},
+	}
+
+	var migrated int64
+	for _, m := range migrations {
+		filter := bson.M{
+			m.field:    bson.M{"$exists": false},
+			m.latField: bson.M{"$gte": -90, "$lte": 90},
+			m.lngField: bson.M{"$gte": -180, "$lte": 180},
+			// 0,0 is what an unset location looks like
+			"$or": []bson.M{
+				{m.latField: bson.M{"$ne": 0}},
+				{m.lngField: bson.M{"$ne": 0}},
+			},
+		}
+		update := mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				m.field: bson.M{
+					"type":        "Point",
+					"coordinates": bson.A{"$" + m.lngField, "$" + m.latField},
+				},
+			}}},
+		}
+
+		result, err := config.GetCollection(m.collection).UpdateMany(ctx, filter, update)
+		if err != nil {
+			return migrated, err
+		}
+		migrated += result.ModifiedCount
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IdempotencyMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewIdempotencyMongoRepository() *IdempotencyMongoRepository {
+	r := &IdempotencyMongoRepository{
+		collection: config.GetCollection("idempotency_keys"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+// ensureIndexes lets MongoDB drop records once they expire
+func (r *IdempotencyMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create idempotency indexes: %v", err)
+	}
+}
+
+func (r *IdempotencyMongoRepository) Reserve(record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A racing retry can replace or release the key between our reads, so
+	// start over a few times rather than guess who owns it
+	for attempt := 0; attempt < 3; attempt++ {
+		_, err := r.collection.InsertOne(ctx, record)
+		if err == nil {
+			return nil, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		var existing entities.IdempotencyRecord
+		err = r.collection.FindOne(ctx, bson.M{"_id": record.Key}).Decode(&existing)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The TTL monitor only runs once a minute, so treat expired records as gone
+		if !time.Now().After(existing.ExpiresAt) {
+			return &existing, nil
+		}
+
+		result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": record.Key, "expiresAt": existing.ExpiresAt}, record)
+		if err != nil {
+			return nil, err
+		}
+		if result.MatchedCount == 1 {
+			return nil, nil
+		}
+		// Another retry took over the expired key first; report its record
+	}
+
+	return nil, errors.New("idempotency key is contended")
+}
+
+func (r *IdempotencyMongoRepository) Complete(key string, responseStatus int, responseBody []byte, contentType string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":         "completed",
+			"responseStatus": responseStatus,
+			"responseBody":   responseBody,
+			"contentType":    contentType,
+			"expiresAt":      expiresAt,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": key}, update)
+	return err
+}
+
+func (r *IdempotencyMongoRepository) Release(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
@@ -0,0 +1,187 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IncidentMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewIncidentMongoRepository() *IncidentMongoRepository {
+	r := &IncidentMongoRepository{
+		collection: config.GetCollection("incidents"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *IncidentMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "deliveryId", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: -1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create incident indexes: %v", err)
+	}
+}
+
+func (r *IncidentMongoRepository) Create(incident *entities.Incident) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	incident.CreatedAt = time.Now()
+	incident.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, incident)
+	if err != nil {
+		return err
+	}
+
+	incident.IncidentID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (r *IncidentMongoRepository) GetByID(incidentID string) (*entities.Incident, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var incident entities.Incident
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&incident)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("incident not found")
+		}
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+func (r *IncidentMongoRepository) GetByDelivery(deliveryID string) ([]entities.Incident, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"deliveryId": deliveryID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var incidents []entities.Incident
+	if err = cursor.All(ctx, &incidents); err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+func (r *IncidentMongoRepository) Find(f *repositories.IncidentFilter, limit, offset int) ([]entities.Incident, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if len(f.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": f.Statuses}
+	}
+	if len(f.Severities) > 0 {
+		filter["severity"] = bson.M{"$in": f.Severities}
+	}
+	if f.Category != "" {
+		filter["category"] = f.Category
+	}
+	if f.PartnerID != "" {
+		filter["partnerId"] = f.PartnerID
+	}
+	if f.DeliveryID != "" {
+		filter["deliveryId"] = f.DeliveryID
+	}
+
+	// Get total count
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var incidents []entities.Incident
+	if err = cursor.All(ctx, &incidents); err != nil {
+		return nil, 0, err
+	}
+
+	return incidents, int(total), nil
+}
+
+func (r *IncidentMongoRepository) Acknowledge(incidentID, actor string) (bool, error) {
+	now := time.Now()
+	return r.transition(incidentID, []string{"open"}, bson.M{
+		"status":         "acknowledged",
+		"acknowledgedBy": actor,
+		"acknowledgedAt": now,
+		"updatedAt":      now,
+	})
+}
+
+func (r *IncidentMongoRepository) Resolve(incidentID, actor, resolution string) (bool, error) {
+	now := time.Now()
+	return r.transition(incidentID, []string{"open", "acknowledged"}, bson.M{
+		"status":     "resolved",
+		"resolvedBy": actor,
+		"resolvedAt": now,
+		"resolution": resolution,
+		"updatedAt":  now,
+	})
+}
+
+func (r *IncidentMongoRepository) transition(incidentID string, from []string, set bson.M) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(incidentID)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id":    objectID,
+		"status": bson.M{"$in": from},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
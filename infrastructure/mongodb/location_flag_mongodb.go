@@ -0,0 +1,211 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LocationFlagMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLocationFlagMongoRepository() *LocationFlagMongoRepository {
+	r := &LocationFlagMongoRepository{
+		collection: config.GetCollection("location_flags"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *LocationFlagMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "partnerId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "deliveryIds", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create location flag indexes: %v", err)
+	}
+}
+
+func (r *LocationFlagMongoRepository) Create(flag *entities.LocationFlag) error {
+	flag.CreatedAt = time.Now()
+	flag.UpdatedAt = time.Now()
+
+	return withTransaction(func(sc mongo.SessionContext) error {
+		result, err := r.collection.InsertOne(sc, flag)
+		if err != nil {
+			return err
+		}
+		flag.FlagID = result.InsertedID.(primitive.ObjectID).Hex()
+
+		return countLocationFlag(sc, flag, 1)
+	})
+}
+
+func (r *LocationFlagMongoRepository) GetByID(flagID string) (*entities.LocationFlag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(flagID)
+	if err != nil {
+		return nil, err
+	}
+
+	var flag entities.LocationFlag
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&flag)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("location flag not found")
+		}
+		return nil, err
+	}
+
+	return &flag, nil
+}
+
+func (r *LocationFlagMongoRepository) Find(f *repositories.LocationFlagFilter, limit, offset int) ([]entities.LocationFlag, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if len(f.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": f.Statuses}
+	}
+	if len(f.Types) > 0 {
+		filter["type"] = bson.M{"$in": f.Types}
+	}
+	if f.PartnerID != "" {
+		filter["partnerId"] = f.PartnerID
+	}
+	if f.DeliveryID != "" {
+		filter["deliveryIds"] = f.DeliveryID
+	}
+
+	// Get total count
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var flags []entities.LocationFlag
+	if err = cursor.All(ctx, &flags); err != nil {
+		return nil, 0, err
+	}
+
+	return flags, int(total), nil
+}
+
+func (r *LocationFlagMongoRepository) HasOpen(partnerID, flagType string, deliveryIDs []string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"partnerId":   partnerID,
+		"type":        flagType,
+		"status":      "open",
+		"deliveryIds": deliveryIDs,
+	}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *LocationFlagMongoRepository) Review(flagID, status, actor, note string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(flagID)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	filter := bson.M{
+		"_id":    objectID,
+		"status": "open",
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"reviewedBy": actor,
+			"reviewedAt": now,
+			"reviewNote": note,
+			"updatedAt":  now,
+		},
+	}
+
+	updated := false
+	err = withTransaction(func(sc mongo.SessionContext) error {
+		var flag entities.LocationFlag
+		err := r.collection.FindOneAndUpdate(sc, filter, update).Decode(&flag)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		updated = true
+
+		return countLocationFlag(sc, &flag, -1)
+	})
+
+	return updated, err
+}
+
+// countLocationFlag moves the open flag counts on the partner and the
+// deliveries as part of the caller's transaction
+func countLocationFlag(sc mongo.SessionContext, flag *entities.LocationFlag, delta int) error {
+	partnerID, err := primitive.ObjectIDFromHex(flag.PartnerID)
+	if err != nil {
+		return err
+	}
+	_, err = config.GetCollection("delivery_partners").UpdateOne(sc,
+		bson.M{"_id": partnerID},
+		bson.M{"$inc": bson.M{"openLocationFlags": delta}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(flag.DeliveryIDs) == 0 {
+		return nil
+	}
+	deliveryIDs := make([]primitive.ObjectID, 0, len(flag.DeliveryIDs))
+	for _, id := range flag.DeliveryIDs {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return err
+		}
+		deliveryIDs = append(deliveryIDs, objectID)
+	}
+	_, err = config.GetCollection("deliveries").UpdateMany(sc,
+		bson.M{"_id": bson.M{"$in": deliveryIDs}},
+		bson.M{"$inc": bson.M{"openLocationFlags": delta}},
+	)
+	return err
+}
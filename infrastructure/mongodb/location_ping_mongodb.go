@@ -0,0 +1,236 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const locationPingCollection = "location_pings"
+
+// locationPingKeyCollection holds one claim per stored fix. Time-series
+// collections can't have unique indexes, so duplicates are caught here.
+const locationPingKeyCollection = "location_ping_keys"
+
+// namespaceExists is the server error code for creating a collection twice
+const namespaceExists = 48
+
+type LocationPingMongoRepository struct {
+	collection *mongo.Collection
+	keys       *mongo.Collection
+}
+
+// NewLocationPingMongoRepository stores pings in a time-series collection that
+// MongoDB expires after retention
+func NewLocationPingMongoRepository(retention time.Duration) *LocationPingMongoRepository {
+	r := &LocationPingMongoRepository{
+		collection: config.GetCollection(locationPingCollection),
+		keys:       config.GetCollection(locationPingKeyCollection),
+	}
+	r.ensureCollection(retention)
+	r.ensureIndexes(retention)
+	return r
+}
+
+func (r *LocationPingMongoRepository) ensureCollection(retention time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	expireAfter := int64(retention.Seconds())
+	opts := options.CreateCollection().
+		SetTimeSeriesOptions(options.TimeSeries().
+			SetTimeField("recordedAt").
+			SetMetaField("partnerId").
+			SetGranularity("seconds")).
+		SetExpireAfterSeconds(expireAfter)
+
+	err := config.Database.CreateCollection(ctx, locationPingCollection, opts)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == namespaceExists {
+		// Keep the retention in step with the config
+		err = config.Database.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: locationPingCollection},
+			{Key: "expireAfterSeconds", Value: expireAfter},
+		}).Err()
+	}
+	if err != nil {
+		log.Printf("⚠️  Failed to create location ping collection: %v", err)
+	}
+}
+
+func (r *LocationPingMongoRepository) ensureIndexes(retention time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "partnerId", Value: 1}, {Key: "recordedAt", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create location ping indexes: %v", err)
+	}
+
+	// Claims expire with the pings they stand for
+	_, err = r.keys.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "createdAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create location ping key indexes: %v", err)
+	}
+}
+
+// pingKey identifies a fix; MongoDB keeps milliseconds, so it matches at that precision
+func pingKey(partnerID string, recordedAt time.Time) string {
+	return fmt.Sprintf("%s:%d", partnerID, recordedAt.UnixMilli())
+}
+
+func (r *LocationPingMongoRepository) Claim(partnerID string, recordedAts []time.Time) ([]time.Time, error) {
+	if len(recordedAts) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	docs := make([]interface{}, len(recordedAts))
+	for i, recordedAt := range recordedAts {
+		docs[i] = bson.M{"_id": pingKey(partnerID, recordedAt), "createdAt": now}
+	}
+
+	_, err := r.keys.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	failed := make(map[int]bool)
+	var failure error
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+			return nil, err
+		}
+		for _, writeErr := range bulkErr.WriteErrors {
+			failed[writeErr.Index] = true
+			if !mongo.IsDuplicateKeyError(writeErr) {
+				failure = err
+			}
+		}
+	}
+
+	claimed := make([]time.Time, 0, len(recordedAts))
+	for i, recordedAt := range recordedAts {
+		if !failed[i] {
+			claimed = append(claimed, recordedAt)
+		}
+	}
+
+	if failure != nil {
+		// Give back what this call did claim so a retry can store it
+		if err := r.Release(partnerID, claimed); err != nil {
+			log.Printf("❌ Failed to release location ping claims for partner %s: %v", partnerID, err)
+		}
+		return nil, failure
+	}
+	return claimed, nil
+}
+
+func (r *LocationPingMongoRepository) Release(partnerID string, recordedAts []time.Time) error {
+	if len(recordedAts) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ids := make([]string, len(recordedAts))
+	for i, recordedAt := range recordedAts {
+		ids[i] = pingKey(partnerID, recordedAt)
+	}
+
+	_, err := r.keys.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+func (r *LocationPingMongoRepository) Insert(ping *entities.LocationPing) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ping.ReceivedAt = time.Now()
+	_, err := r.collection.InsertOne(ctx, ping)
+	return err
+}
+
+func (r *LocationPingMongoRepository) InsertMany(pings []entities.LocationPing) error {
+	if len(pings) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	docs := make([]interface{}, len(pings))
+	for i := range pings {
+		pings[i].ReceivedAt = now
+		docs[i] = pings[i]
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+func (r *LocationPingMongoRepository) GetLatest(partnerID string, before time.Time) (*entities.LocationPing, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"partnerId":  partnerID,
+		"recordedAt": bson.M{"$lt": before},
+	}
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "recordedAt", Value: -1}}).
+		SetProjection(bson.M{"_id": 0})
+
+	var ping entities.LocationPing
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&ping)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &ping, nil
+}
+
+func (r *LocationPingMongoRepository) GetTrail(partnerID string, from, to time.Time, limit int) ([]entities.LocationPing, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"partnerId":  partnerID,
+		"recordedAt": bson.M{"$gte": from, "$lte": to},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "recordedAt", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"_id": 0})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pings []entities.LocationPing
+	if err = cursor.All(ctx, &pings); err != nil {
+		return nil, err
+	}
+
+	return pings, nil
+}
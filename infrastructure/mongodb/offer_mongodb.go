@@ -0,0 +1,302 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OfferMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOfferMongoRepository() *OfferMongoRepository {
+	r := &OfferMongoRepository{
+		collection: config.GetCollection("offers"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *OfferMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "deliveryId", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "partnerId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "expiresAt", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create offer indexes: %v", err)
+	}
+}
+
+func (r *OfferMongoRepository) CreateMany(offers []entities.Offer) error {
+	if len(offers) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(offers))
+	for i := range offers {
+		offers[i].CreatedAt = now
+		offers[i].UpdatedAt = now
+		docs = append(docs, offers[i])
+	}
+
+	result, err := r.collection.InsertMany(ctx, docs)
+	if err != nil {
+		return err
+	}
+
+	for i, id := range result.InsertedIDs {
+		offers[i].OfferID = id.(primitive.ObjectID).Hex()
+	}
+	return nil
+}
+
+func (r *OfferMongoRepository) GetPendingByDelivery(deliveryID string) ([]entities.Offer, error) {
+	return r.find(bson.M{"deliveryId": deliveryID, "status": "pending"})
+}
+
+func (r *OfferMongoRepository) GetPendingByPartner(partnerID string) ([]entities.Offer, error) {
+	return r.find(bson.M{"partnerId": partnerID, "status": "pending"})
+}
+
+func (r *OfferMongoRepository) find(filter bson.M) ([]entities.Offer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "expiresAt", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var offers []entities.Offer
+	if err = cursor.All(ctx, &offers); err != nil {
+		return nil, err
+	}
+
+	return offers, nil
+}
+
+func (r *OfferMongoRepository) GetDeliveriesWithPendingOffers(deliveryIDs []string) (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	values, err := r.collection.Distinct(ctx, "deliveryId", bson.M{
+		"deliveryId": bson.M{"$in": deliveryIDs},
+		"status":     "pending",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]bool, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			pending[id] = true
+		}
+	}
+	return pending, nil
+}
+
+func (r *OfferMongoRepository) CountPendingByPartners(partnerIDs []string) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"partnerId": bson.M{"$in": partnerIDs},
+			"status":    "pending",
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$partnerId",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		PartnerID string `bson:"_id"`
+		Count     int    `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.PartnerID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *OfferMongoRepository) GetOfferedPartners(deliveryID string, since time.Time) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	values, err := r.collection.Distinct(ctx, "partnerId", bson.M{
+		"deliveryId": deliveryID,
+		"createdAt":  bson.M{"$gte": since},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	partnerIDs := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			partnerIDs = append(partnerIDs, id)
+		}
+	}
+	return partnerIDs, nil
+}
+
+func (r *OfferMongoRepository) Respond(offerID, status, reason string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(offerID)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	set := bson.M{
+		"status":      status,
+		"respondedAt": now,
+		"updatedAt":   now,
+	}
+	if reason != "" {
+		set["rejectReason"] = reason
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "status": "pending"}, bson.M{"$set": set})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *OfferMongoRepository) WithdrawPending(deliveryID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":    "withdrawn",
+			"updatedAt": time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{"deliveryId": deliveryID, "status": "pending"}, update)
+	return err
+}
+
+func (r *OfferMongoRepository) ExpireDue(now time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":    "pending",
+		"expiresAt": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":    "expired",
+			"updatedAt": now,
+		},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.ModifiedCount), nil
+}
+
+func (r *OfferMongoRepository) GetStatsByPartner(partnerID, period string) (*entities.OfferStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var startDate time.Time
+	now := time.Now()
+
+	switch period {
+	case "today":
+		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	case "week":
+		startDate = now.AddDate(0, 0, -7)
+	case "month":
+		startDate = now.AddDate(0, -1, 0)
+	default:
+		startDate = now.AddDate(0, 0, -7)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"partnerId": partnerID,
+			"createdAt": bson.M{"$gte": startDate},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$status",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Status string `bson:"_id"`
+		Count  int    `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	stats := &entities.OfferStats{
+		PartnerID: partnerID,
+		Period:    period,
+	}
+	for _, row := range rows {
+		stats.Offered += row.Count
+		switch row.Status {
+		case "accepted":
+			stats.Accepted = row.Count
+		case "rejected":
+			stats.Rejected = row.Count
+		case "expired":
+			stats.Expired = row.Count
+		case "withdrawn":
+			stats.Withdrawn = row.Count
+		case "pending":
+			stats.Pending = row.Count
+		}
+	}
+
+	return stats, nil
+}
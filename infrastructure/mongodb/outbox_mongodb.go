@@ -0,0 +1,158 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxMongoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewOutboxMongoRepository keeps published messages for retention
+func NewOutboxMongoRepository(retention time.Duration) *OutboxMongoRepository {
+	r := &OutboxMongoRepository{
+		collection: config.GetCollection("outbox"),
+	}
+	r.ensureIndexes(retention)
+	return r
+}
+
+func (r *OutboxMongoRepository) ensureIndexes(retention time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// The relay's claim query
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}}},
+		// Only published messages have publishedAt, so pending ones stay
+		{
+			Keys:    bson.D{{Key: "publishedAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+		},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create outbox indexes: %v", err)
+	}
+}
+
+// withTransaction runs fn inside a multi-document transaction.
+// MongoDB must run as a replica set for this to work.
+func withTransaction(fn func(sc mongo.SessionContext) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	session, err := config.Client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+// insertOutboxEvent queues an event as part of the caller's transaction
+func insertOutboxEvent(sc mongo.SessionContext, event *entities.DeliveryEvent) error {
+	if event == nil {
+		return nil
+	}
+
+	now := time.Now()
+	message := entities.OutboxMessage{
+		Event:         *event,
+		Status:        "pending",
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	_, err := config.GetCollection("outbox").InsertOne(sc, message)
+	return err
+}
+
+// ClaimPending leases pending messages by pushing nextAttemptAt forward so that
+// parallel relays do not publish the same message twice.
+func (r *OutboxMongoRepository) ClaimPending(limit int, lease time.Duration) ([]entities.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	messages := make([]entities.OutboxMessage, 0, limit)
+	for len(messages) < limit {
+		now := time.Now()
+		filter := bson.M{
+			"status":        "pending",
+			"nextAttemptAt": bson.M{"$lte": now},
+		}
+		update := bson.M{
+			"$set": bson.M{"nextAttemptAt": now.Add(lease)},
+		}
+		opts := options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+			SetReturnDocument(options.After)
+
+		var message entities.OutboxMessage
+		err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&message)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				break
+			}
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func (r *OutboxMongoRepository) MarkPublished(messageID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":      "published",
+			"publishedAt": time.Now(),
+			"lastError":   "",
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *OutboxMongoRepository) MarkFailed(messageID string, attempts int, nextAttemptAt time.Time, lastError string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"attempts":      attempts,
+			"nextAttemptAt": nextAttemptAt,
+			"lastError":     lastError,
+		},
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
@@ -0,0 +1,26 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MigrateHeartbeats starts the presence clock for partners written before
+// heartbeats existed, so the first sweep doesn't take them all offline. Partners
+// that already have a heartbeat are left alone, so it is safe to run on every start.
+func MigrateHeartbeats() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	result, err := config.GetCollection("delivery_partners").UpdateMany(ctx,
+		bson.M{"lastHeartbeatAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"lastHeartbeatAt": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
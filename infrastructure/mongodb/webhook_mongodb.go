@@ -0,0 +1,272 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookMongoRepository struct {
+	subscriptions *mongo.Collection
+	messages      *mongo.Collection
+}
+
+// NewWebhookMongoRepository keeps delivered and dead messages for retention
+func NewWebhookMongoRepository(retention time.Duration) *WebhookMongoRepository {
+	r := &WebhookMongoRepository{
+		subscriptions: config.GetCollection("webhook_subscriptions"),
+		messages:      config.GetCollection("webhook_messages"),
+	}
+	r.ensureIndexes(retention)
+	return r
+}
+
+func (r *WebhookMongoRepository) ensureIndexes(retention time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	expireAfter := int32(retention.Seconds())
+	_, err := r.messages.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// The worker's claim query
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "deadAt", Value: -1}}},
+		// Each only applies to messages that have the field, so pending ones stay
+		{Keys: bson.D{{Key: "deliveredAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(expireAfter)},
+		{Keys: bson.D{{Key: "deadAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(expireAfter)},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create webhook message indexes: %v", err)
+	}
+}
+
+func (r *WebhookMongoRepository) CreateSubscription(subscription *entities.WebhookSubscription) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	subscription.CreatedAt = time.Now()
+	subscription.UpdatedAt = time.Now()
+
+	result, err := r.subscriptions.InsertOne(ctx, subscription)
+	if err != nil {
+		return err
+	}
+
+	subscription.SubscriptionID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (r *WebhookMongoRepository) GetSubscriptions(activeOnly bool) ([]entities.WebhookSubscription, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if activeOnly {
+		filter["isActive"] = true
+	}
+
+	cursor, err := r.subscriptions.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []entities.WebhookSubscription
+	if err = cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebhookMongoRepository) DeleteSubscription(subscriptionID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.subscriptions.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("subscription not found")
+	}
+	return nil
+}
+
+func (r *WebhookMongoRepository) EnqueueMessages(messages []entities.WebhookMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(messages))
+	for i := range messages {
+		messages[i].CreatedAt = now
+		messages[i].UpdatedAt = now
+		docs = append(docs, messages[i])
+	}
+
+	_, err := r.messages.InsertMany(ctx, docs)
+	return err
+}
+
+// ClaimDueMessages leases pending messages whose next attempt is due by pushing
+// their nextAttemptAt forward, so parallel workers do not send the same message.
+func (r *WebhookMongoRepository) ClaimDueMessages(limit int, lease time.Duration) ([]entities.WebhookMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	messages := make([]entities.WebhookMessage, 0, limit)
+	for len(messages) < limit {
+		now := time.Now()
+		filter := bson.M{
+			"status":        "pending",
+			"nextAttemptAt": bson.M{"$lte": now},
+		}
+		update := bson.M{
+			"$set": bson.M{
+				"nextAttemptAt": now.Add(lease),
+				"updatedAt":     now,
+			},
+		}
+		opts := options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+			SetReturnDocument(options.After)
+
+		var message entities.WebhookMessage
+		err := r.messages.FindOneAndUpdate(ctx, filter, update, opts).Decode(&message)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				break
+			}
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func (r *WebhookMongoRepository) MarkDelivered(messageID string, attempts int, statusCode int) error {
+	now := time.Now()
+	return r.updateMessage(messageID, bson.M{
+		"status":         "delivered",
+		"attempts":       attempts,
+		"lastStatusCode": statusCode,
+		"lastError":      "",
+		"deliveredAt":    now,
+		"updatedAt":      now,
+	})
+}
+
+func (r *WebhookMongoRepository) MarkRetry(messageID string, attempts int, nextAttemptAt time.Time, statusCode int, lastError string) error {
+	return r.updateMessage(messageID, bson.M{
+		"attempts":       attempts,
+		"nextAttemptAt":  nextAttemptAt,
+		"lastStatusCode": statusCode,
+		"lastError":      lastError,
+		"updatedAt":      time.Now(),
+	})
+}
+
+func (r *WebhookMongoRepository) MarkDead(messageID string, attempts int, statusCode int, lastError string) error {
+	now := time.Now()
+	return r.updateMessage(messageID, bson.M{
+		"status":         "dead",
+		"attempts":       attempts,
+		"lastStatusCode": statusCode,
+		"lastError":      lastError,
+		"deadAt":         now,
+		"updatedAt":      now,
+	})
+}
+
+func (r *WebhookMongoRepository) GetDeadLetters(limit, offset int) ([]entities.WebhookMessage, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": "dead"}
+
+	// Get total count
+	total, err := r.messages.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	opts := options.Find().
+		SetSort(bson.D{{Key: "deadAt", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.messages.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []entities.WebhookMessage
+	if err = cursor.All(ctx, &messages); err != nil {
+		return nil, 0, err
+	}
+
+	return messages, int(total), nil
+}
+
+func (r *WebhookMongoRepository) Redeliver(messageID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":        "pending",
+			"attempts":      0,
+			"nextAttemptAt": now,
+			"updatedAt":     now,
+		},
+		"$unset": bson.M{"deadAt": ""},
+	}
+
+	result, err := r.messages.UpdateOne(ctx, bson.M{"_id": objectID, "status": "dead"}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("dead letter not found")
+	}
+	return nil
+}
+
+func (r *WebhookMongoRepository) updateMessage(messageID string, set bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.messages.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": set})
+	return err
+}
@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"context"
+	"deliveryAppBackend/config"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ZoneMongoRepository struct {
+	collection *mongo.Collection
+}
+
+func NewZoneMongoRepository() *ZoneMongoRepository {
+	r := &ZoneMongoRepository{
+		collection: config.GetCollection("zones"),
+	}
+	r.ensureIndexes()
+	return r
+}
+
+func (r *ZoneMongoRepository) ensureIndexes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "area", Value: "2dsphere"}}},
+		{Keys: bson.D{{Key: "warehouseIds", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to create zone indexes: %v", err)
+	}
+}
+
+func (r *ZoneMongoRepository) Create(zone *entities.Zone) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	zone.CreatedAt = time.Now()
+	zone.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	zone.ZoneID = result.InsertedID.(primitive.ObjectID).Hex()
+	return nil
+}
+
+func (r *ZoneMongoRepository) GetByID(zoneID string) (*entities.Zone, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(zoneID)
+	if err != nil {
+		return nil, repositories.ErrZoneNotFound
+	}
+
+	var zone entities.Zone
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&zone)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repositories.ErrZoneNotFound
+		}
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+func (r *ZoneMongoRepository) Update(zone *entities.Zone) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(zone.ZoneID)
+	if err != nil {
+		return err
+	}
+
+	zone.UpdatedAt = time.Now()
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{
+		"$set": bson.M{
+			"name":         zone.Name,
+			"warehouseIds": zone.WarehouseIDs,
+			"area":         zone.Area,
+			"isActive":     zone.IsActive,
+			"updatedAt":    zone.UpdatedAt,
+		},
+	})
+	return err
+}
+
+func (r *ZoneMongoRepository) GetAll(warehouseID string, includeInactive bool) ([]entities.Zone, error) {
+	filter := bson.M{}
+	if warehouseID != "" {
+		filter["warehouseIds"] = warehouseID
+	}
+	if !includeInactive {
+		filter["isActive"] = true
+	}
+	return r.find(filter)
+}
+
+func (r *ZoneMongoRepository) FindContaining(point entities.GeoPoint) ([]entities.Zone, error) {
+	return r.find(bson.M{
+		"isActive": true,
+		"area": bson.M{
+			"$geoIntersects": bson.M{"$geometry": point},
+		},
+	})
+}
+
+func (r *ZoneMongoRepository) GetByWarehouse(warehouseID string) ([]entities.Zone, error) {
+	return r.find(bson.M{
+		"isActive":     true,
+		"warehouseIds": warehouseID,
+	})
+}
+
+func (r *ZoneMongoRepository) find(filter bson.M) ([]entities.Zone, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	zones := make([]entities.Zone, 0)
+	if err = cursor.All(ctx, &zones); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
@@ -0,0 +1,26 @@
+package notifier
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"log"
+)
+
+// LogNotifier is used for local development. It logs that a notification
+// was sent, but not its title or message, which can carry what a partner or
+// customer wrote.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Name() string {
+	return "log"
+}
+
+func (n *LogNotifier) Notify(notification *entities.Notification) error {
+	log.Printf("🔔 [%s] %s to %s (delivery %s, order %s, partner %s, incident %s)",
+		notification.Severity, notification.Type, notification.Audience,
+		notification.DeliveryID, notification.OrderID, notification.PartnerID, notification.Data["incidentId"])
+	return nil
+}
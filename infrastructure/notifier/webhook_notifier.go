@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"bytes"
+	"deliveryAppBackend/domain/entities"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookNotifier posts notifications as JSON to a single URL, e.g. a chat
+// incoming webhook or the support tool. The "text" field carries a one-line
+// summary for chat tools that only render that.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type webhookNotification struct {
+	Text string `json:"text"`
+	*entities.Notification
+}
+
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+func (n *WebhookNotifier) Notify(notification *entities.Notification) error {
+	body, err := json.Marshal(webhookNotification{
+		Text:         fmt.Sprintf("[%s] %s: %s", notification.Severity, notification.Title, notification.Message),
+		Notification: notification,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.httpClient.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook responded with %d", resp.StatusCode)
+	}
+	return nil
+}
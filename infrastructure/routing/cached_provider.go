@@ -0,0 +1,93 @@
+package routing
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/services"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type cachedRoute struct {
+	route     entities.Route
+	expiresAt time.Time
+}
+
+// CachedRouteProvider remembers routes per coordinate pair. Coordinates are
+// rounded to 4 decimals (about 11 m) so a rider standing still hits the cache.
+type CachedRouteProvider struct {
+	provider   services.RouteProvider
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]cachedRoute
+}
+
+func NewCachedRouteProvider(provider services.RouteProvider, ttl time.Duration, maxEntries int) *CachedRouteProvider {
+	return &CachedRouteProvider{
+		provider:   provider,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]cachedRoute),
+	}
+}
+
+func (p *CachedRouteProvider) Name() string {
+	return p.provider.Name()
+}
+
+func (p *CachedRouteProvider) Route(from, to entities.Coordinate) (*entities.Route, error) {
+	key := fmt.Sprintf("%.4f,%.4f;%.4f,%.4f", from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	now := time.Now()
+
+	p.mu.Lock()
+	cached, ok := p.entries[key]
+	p.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		route := cached.route
+		return &route, nil
+	}
+
+	route, err := p.provider.Route(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.entries) >= p.maxEntries {
+		p.evict(now)
+	}
+	p.entries[key] = cachedRoute{route: *route, expiresAt: now.Add(p.ttl)}
+
+	return route, nil
+}
+
+// Matrix is not cached: the stops of a run change with every order, so a
+// whole table rarely repeats
+func (p *CachedRouteProvider) Matrix(points []entities.Coordinate) (*entities.RouteMatrix, error) {
+	return p.provider.Matrix(points)
+}
+
+// evict drops expired entries, and if that frees nothing, an arbitrary half of
+// the cache. Must be called with the lock held.
+func (p *CachedRouteProvider) evict(now time.Time) {
+	for key, cached := range p.entries {
+		if !now.Before(cached.expiresAt) {
+			delete(p.entries, key)
+		}
+	}
+	if len(p.entries) < p.maxEntries {
+		return
+	}
+
+	drop := len(p.entries) / 2
+	for key := range p.entries {
+		if drop == 0 {
+			break
+		}
+		delete(p.entries, key)
+		drop--
+	}
+}
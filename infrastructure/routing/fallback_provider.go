@@ -0,0 +1,72 @@
+package routing
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/services"
+	"log"
+	"sync"
+	"time"
+)
+
+// FallbackRouteProvider uses the primary provider and falls back to the
+// secondary one when it fails, so an unreachable routing engine degrades ETAs
+// instead of breaking them. After a failure the primary is skipped for the
+// cooldown, so requests during an outage don't each wait out its timeout.
+type FallbackRouteProvider struct {
+	primary  services.RouteProvider
+	fallback services.RouteProvider
+	cooldown time.Duration
+
+	mu        sync.Mutex
+	downUntil time.Time
+}
+
+func NewFallbackRouteProvider(primary, fallback services.RouteProvider, cooldown time.Duration) *FallbackRouteProvider {
+	return &FallbackRouteProvider{
+		primary:  primary,
+		fallback: fallback,
+		cooldown: cooldown,
+	}
+}
+
+func (p *FallbackRouteProvider) Name() string {
+	return p.primary.Name()
+}
+
+func (p *FallbackRouteProvider) Route(from, to entities.Coordinate) (*entities.Route, error) {
+	if p.primaryUp() {
+		route, err := p.primary.Route(from, to)
+		if err == nil {
+			return route, nil
+		}
+		p.markDown("route", err)
+	}
+
+	return p.fallback.Route(from, to)
+}
+
+func (p *FallbackRouteProvider) Matrix(points []entities.Coordinate) (*entities.RouteMatrix, error) {
+	if p.primaryUp() {
+		matrix, err := p.primary.Matrix(points)
+		if err == nil {
+			return matrix, nil
+		}
+		p.markDown("matrix", err)
+	}
+
+	return p.fallback.Matrix(points)
+}
+
+func (p *FallbackRouteProvider) primaryUp() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !time.Now().Before(p.downUntil)
+}
+
+func (p *FallbackRouteProvider) markDown(lookup string, err error) {
+	p.mu.Lock()
+	p.downUntil = time.Now().Add(p.cooldown)
+	p.mu.Unlock()
+
+	log.Printf("⚠️  %s %s lookup failed, using %s for %s: %v", p.primary.Name(), lookup, p.fallback.Name(), p.cooldown, err)
+}
@@ -0,0 +1,68 @@
+package routing
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/utils"
+	"time"
+)
+
+// Average city speed used to turn a straight-line estimate into a duration
+const haversineSpeedKmh = 20
+
+// HaversineRouteProvider needs no routing engine. It takes the great-circle
+// distance and stretches it by a detour factor to approximate the road distance.
+type HaversineRouteProvider struct {
+	detourFactor float64
+}
+
+func NewHaversineRouteProvider(detourFactor float64) *HaversineRouteProvider {
+	if detourFactor < 1 {
+		detourFactor = 1
+	}
+	return &HaversineRouteProvider{
+		detourFactor: detourFactor,
+	}
+}
+
+func (p *HaversineRouteProvider) Name() string {
+	return "haversine"
+}
+
+func (p *HaversineRouteProvider) Route(from, to entities.Coordinate) (*entities.Route, error) {
+	distanceKm := utils.HaversineKm(from.Latitude, from.Longitude, to.Latitude, to.Longitude) * p.detourFactor
+	duration := time.Duration(distanceKm / haversineSpeedKmh * float64(time.Hour))
+
+	return &entities.Route{
+		DistanceKm:      distanceKm,
+		DurationSeconds: int(duration.Seconds()),
+		Source:          p.Name(),
+	}, nil
+}
+
+func (p *HaversineRouteProvider) Matrix(points []entities.Coordinate) (*entities.RouteMatrix, error) {
+	matrix := newRouteMatrix(len(points), p.Name())
+	for i, from := range points {
+		for j, to := range points {
+			if i == j {
+				continue
+			}
+			route, _ := p.Route(from, to)
+			matrix.DistancesKm[i][j] = route.DistanceKm
+			matrix.DurationsSeconds[i][j] = route.DurationSeconds
+		}
+	}
+	return matrix, nil
+}
+
+func newRouteMatrix(size int, source string) *entities.RouteMatrix {
+	matrix := &entities.RouteMatrix{
+		DistancesKm:      make([][]float64, size),
+		DurationsSeconds: make([][]int, size),
+		Source:           source,
+	}
+	for i := 0; i < size; i++ {
+		matrix.DistancesKm[i] = make([]float64, size)
+		matrix.DurationsSeconds[i] = make([]int, size)
+	}
+	return matrix
+}
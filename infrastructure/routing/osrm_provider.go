@@ -0,0 +1,117 @@
+package routing
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OSRMRouteProvider queries the route service of an OSRM-compatible server,
+// e.g. a local osrm-routed at http://localhost:5000.
+type OSRMRouteProvider struct {
+	baseURL    string
+	profile    string
+	httpClient *http.Client
+}
+
+func NewOSRMRouteProvider(baseURL, profile string) *OSRMRouteProvider {
+	return &OSRMRouteProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		profile:    profile,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type osrmRouteResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Routes  []struct {
+		Distance float64 `json:"distance"` // metres
+		Duration float64 `json:"duration"` // seconds
+	} `json:"routes"`
+}
+
+type osrmTableResponse struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Distances [][]*float64 `json:"distances"` // metres, null when unreachable
+	Durations [][]*float64 `json:"durations"` // seconds, null when unreachable
+}
+
+func (p *OSRMRouteProvider) Name() string {
+	return "osrm"
+}
+
+func (p *OSRMRouteProvider) Route(from, to entities.Coordinate) (*entities.Route, error) {
+	// OSRM takes longitude first
+	url := fmt.Sprintf("%s/route/v1/%s/%f,%f;%f,%f?overview=false",
+		p.baseURL, p.profile, from.Longitude, from.Latitude, to.Longitude, to.Latitude)
+
+	resp, err := p.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body osrmRouteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("osrm responded with %d: %v", resp.StatusCode, err)
+	}
+	if body.Code != "Ok" {
+		return nil, fmt.Errorf("osrm responded with %s: %s", body.Code, body.Message)
+	}
+	if len(body.Routes) == 0 {
+		return nil, fmt.Errorf("osrm returned no route")
+	}
+
+	return &entities.Route{
+		DistanceKm:      body.Routes[0].Distance / 1000,
+		DurationSeconds: int(body.Routes[0].Duration),
+		Source:          p.Name(),
+	}, nil
+}
+
+func (p *OSRMRouteProvider) Matrix(points []entities.Coordinate) (*entities.RouteMatrix, error) {
+	coordinates := make([]string, 0, len(points))
+	for _, point := range points {
+		coordinates = append(coordinates, fmt.Sprintf("%f,%f", point.Longitude, point.Latitude))
+	}
+	url := fmt.Sprintf("%s/table/v1/%s/%s?annotations=distance,duration",
+		p.baseURL, p.profile, strings.Join(coordinates, ";"))
+
+	resp, err := p.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body osrmTableResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("osrm responded with %d: %v", resp.StatusCode, err)
+	}
+	if body.Code != "Ok" {
+		return nil, fmt.Errorf("osrm responded with %s: %s", body.Code, body.Message)
+	}
+	if len(body.Distances) != len(points) || len(body.Durations) != len(points) {
+		return nil, fmt.Errorf("osrm returned a %dx%d table for %d points", len(body.Distances), len(body.Durations), len(points))
+	}
+
+	matrix := newRouteMatrix(len(points), p.Name())
+	for i := range points {
+		if len(body.Distances[i]) != len(points) || len(body.Durations[i]) != len(points) {
+			return nil, fmt.Errorf("osrm returned a short table row")
+		}
+		for j := range points {
+			if body.Distances[i][j] == nil || body.Durations[i][j] == nil {
+				return nil, fmt.Errorf("osrm found no route between points %d and %d", i, j)
+			}
+			matrix.DistancesKm[i][j] = *body.Distances[i][j] / 1000
+			matrix.DurationsSeconds[i][j] = int(*body.Durations[i][j])
+		}
+	}
+
+	return matrix, nil
+}
@@ -0,0 +1,156 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// responseRecorder keeps a copy of everything the handler writes
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware makes POST and PUT requests that carry an
+// Idempotency-Key header safe to retry. The first response for a key is stored
+// for ttl and replayed on retries; reusing a key with a different request is
+// rejected. A key is only held for lease while its request runs, so one
+// orphaned by a crash frees up soon. Must run after AuthMiddleware, since keys
+// are scoped per partner.
+func IdempotencyMiddleware(repo repositories.IdempotencyRepository, ttl, lease time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" || (c.Request.Method != http.MethodPost && c.Request.Method != http.MethodPut) {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Idempotency-Key is too long",
+			})
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Failed to read request body",
+			})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		partnerID := c.GetString("partnerId")
+		now := time.Now()
+		record := &entities.IdempotencyRecord{
+			Key:         partnerID + ":" + key,
+			PartnerID:   partnerID,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			Fingerprint: requestFingerprint(c.Request.Method, c.Request.URL.Path, body),
+			Status:      "in_progress",
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(lease),
+		}
+
+		existing, err := repo.Reserve(record)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to check Idempotency-Key",
+			})
+			c.Abort()
+			return
+		}
+
+		if existing != nil {
+			replayIdempotentResponse(c, existing, record.Fingerprint)
+			return
+		}
+
+		// Server errors and panics are not stored so the client can retry them;
+		// the release is deferred because Recovery skips the rest of this func
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := repo.Release(record.Key); err != nil {
+				log.Printf("❌ Failed to release idempotency key %s: %v", record.Key, err)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		completed = true
+
+		if err := repo.Complete(record.Key, status, recorder.body.Bytes(), recorder.Header().Get("Content-Type"), time.Now().Add(ttl)); err != nil {
+			log.Printf("❌ Failed to store idempotent response for %s: %v", record.Key, err)
+		}
+	}
+}
+
+func replayIdempotentResponse(c *gin.Context, existing *entities.IdempotencyRecord, fingerprint string) {
+	defer c.Abort()
+
+	if existing.Fingerprint != fingerprint {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"success": false,
+			"error":   "Idempotency-Key was already used for a different request",
+		})
+		return
+	}
+
+	if existing.Status != "completed" {
+		c.JSON(http.StatusConflict, gin.H{
+			"success": false,
+			"error":   "A request with this Idempotency-Key is still being processed",
+		})
+		return
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(existing.ResponseStatus, existing.ContentType, existing.ResponseBody)
+}
+
+func requestFingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte(" "))
+	hash.Write([]byte(path))
+	hash.Write([]byte("\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
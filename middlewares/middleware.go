@@ -1,8 +1,10 @@
 package middlewares
 
 import (
+	"crypto/subtle"
 	"deliveryAppBackend/utils"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -51,3 +53,38 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+
+// OpsAuthMiddleware guards internal endpoints used by warehouse staff, ops and
+// other eSpaze services. Callers authenticate with the shared OPS_API_KEY.
+func OpsAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := os.Getenv("OPS_API_KEY")
+		if apiKey == "" {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"success": false,
+				"error":   "Ops access is not configured",
+			})
+			c.Abort()
+			return
+		}
+
+		providedKey := c.GetHeader("X-Ops-Key")
+		if subtle.ConstantTimeCompare([]byte(providedKey), []byte(apiKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "Invalid ops key",
+			})
+			c.Abort()
+			return
+		}
+
+		// Actor is recorded on audited actions
+		actor := c.GetHeader("X-Ops-Actor")
+		if actor == "" {
+			actor = "ops"
+		}
+		c.Set("opsActor", actor)
+
+		c.Next()
+	}
+}
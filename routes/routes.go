@@ -1,31 +1,181 @@
 package routes
 
 import (
+	"context"
+	"deliveryAppBackend/domain/services"
 	"deliveryAppBackend/handlers"
+	"deliveryAppBackend/infrastructure/callbridge"
 	"deliveryAppBackend/infrastructure/mongodb"
+	"deliveryAppBackend/infrastructure/notifier"
+	"deliveryAppBackend/infrastructure/routing"
 	"deliveryAppBackend/middlewares"
 	"deliveryAppBackend/usecase"
+	"deliveryAppBackend/utils"
+	"log"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 func SetupRoutes(router *gin.Engine) {
+	// Initialize external services
+	var callBridge services.CallBridge
+	switch os.Getenv("CALL_BRIDGE_DRIVER") {
+	case "http":
+		callBridge = callbridge.NewHTTPCallBridge(os.Getenv("CALL_BRIDGE_URL"), os.Getenv("CALL_BRIDGE_API_KEY"))
+	case "fake":
+		callBridge = callbridge.NewFakeCallBridge()
+	default:
+		// Fail closed in production rather than pretend calls are placed
+		callBridge = callbridge.NewFakeCallBridge()
+		if gin.Mode() == gin.ReleaseMode {
+			log.Printf("⚠️  CALL_BRIDGE_DRIVER is not set; customer calls are disabled")
+			callBridge = callbridge.NewDisabledCallBridge()
+		}
+	}
+
+	var supportNotifier services.Notifier = notifier.NewLogNotifier()
+	if os.Getenv("NOTIFIER_DRIVER") == "webhook" {
+		supportNotifier = notifier.NewWebhookNotifier(os.Getenv("NOTIFIER_WEBHOOK_URL"))
+	}
+
+	var routeProvider services.RouteProvider = routing.NewHaversineRouteProvider(utils.GetEnvFloat("ROUTE_DETOUR_FACTOR", 1.3))
+	if os.Getenv("ROUTE_PROVIDER_DRIVER") == "osrm" {
+		// Only real routes are cached; the haversine fallback is cheap to recompute
+		osrm := routing.NewCachedRouteProvider(
+			routing.NewOSRMRouteProvider(utils.GetEnv("OSRM_URL", "http://localhost:5000"), utils.GetEnv("OSRM_PROFILE", "driving")),
+			utils.GetEnvDuration("ROUTE_CACHE_TTL", time.Hour),
+			utils.GetEnvInt("ROUTE_CACHE_SIZE", 10000),
+		)
+		routeProvider = routing.NewFallbackRouteProvider(osrm, routeProvider, utils.GetEnvDuration("ROUTE_PROVIDER_COOLDOWN", 30*time.Second))
+	}
+
+	// Location history, and the keys that de-duplicate it, are kept this long
+	locationRetention := utils.GetEnvDuration("LOCATION_RETENTION", 30*24*time.Hour)
+
 	// Initialize repositories
 	partnerRepo := mongodb.NewDeliveryPartnerMongoRepository()
 	deliveryRepo := mongodb.NewDeliveryMongoRepository()
 	earningsRepo := mongodb.NewEarningsMongoRepository()
+	webhookRepo := mongodb.NewWebhookMongoRepository(utils.GetEnvDuration("WEBHOOK_MESSAGE_RETENTION", 30*24*time.Hour))
+	outboxRepo := mongodb.NewOutboxMongoRepository(utils.GetEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour))
+	idempotencyRepo := mongodb.NewIdempotencyMongoRepository()
+	feedbackRepo := mongodb.NewFeedbackMongoRepository()
+	incidentRepo := mongodb.NewIncidentMongoRepository()
+	chatRepo := mongodb.NewChatMongoRepository()
+	offerRepo := mongodb.NewOfferMongoRepository()
+	zoneRepo := mongodb.NewZoneMongoRepository()
+	pingRepo := mongodb.NewLocationPingMongoRepository(locationRetention)
+	flagRepo := mongodb.NewLocationFlagMongoRepository()
+
+	go func() {
+		migrated, err := mongodb.MigrateGeoLocations()
+		if err != nil {
+			log.Printf("❌ GeoJSON location migration failed: %v", err)
+		} else if migrated > 0 {
+			log.Printf("🗺️  Backfilled %d GeoJSON locations", migrated)
+		}
+	}()
+
+	go func() {
+		migrated, err := mongodb.MigrateHeartbeats()
+		if err != nil {
+			log.Printf("❌ Heartbeat migration failed: %v", err)
+		} else if migrated > 0 {
+			log.Printf("📡 Started the heartbeat clock for %d partners", migrated)
+		}
+	}()
+
+	// Live tracking fan-out. The hub is in-process, so with several instances a
+	// stream only sees the pings and events handled by its own instance.
+	streamHub := usecase.NewStreamHub(utils.GetEnvInt("STREAM_BUFFER_SIZE", 64), utils.GetEnvInt("STREAM_MAX_DROPPED", 64))
+
+	// Deliver-now orders are due this long after they come in
+	deliverySLA := utils.GetEnvDuration("DELIVERY_SLA", 45*time.Minute)
 
 	// Initialize use cases
 	authUseCase := usecase.NewAuthUseCase(partnerRepo)
-	deliveryUseCase := usecase.NewDeliveryUseCase(deliveryRepo, partnerRepo, earningsRepo)
-	profileUseCase := usecase.NewProfileUseCase(partnerRepo)
+	webhookUseCase := usecase.NewWebhookUseCase(webhookRepo, utils.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 8))
+	trackingUseCase := usecase.NewTrackingUseCase(deliveryRepo, partnerRepo, os.Getenv("TRACKING_BASE_URL"), utils.GetEnvDuration("TRACKING_LINK_TTL", 24*time.Hour))
+	profileUseCase := usecase.NewProfileUseCase(partnerRepo, deliveryRepo)
+	integrityUseCase := usecase.NewLocationIntegrityUseCase(flagRepo, pingRepo, deliveryRepo, usecase.IntegrityConfig{
+		MaxSpeedKmh:    utils.GetEnvFloat("LOCATION_MAX_SPEED_KMH", 150),
+		TeleportKm:     utils.GetEnvFloat("LOCATION_TELEPORT_KM", 2),
+		TeleportWindow: utils.GetEnvDuration("LOCATION_TELEPORT_WINDOW", 10*time.Second),
+	})
+	locationUseCase := usecase.NewLocationUseCase(partnerRepo, deliveryRepo, pingRepo, integrityUseCase, streamHub, locationRetention)
+	deliveryUseCase := usecase.NewDeliveryUseCase(deliveryRepo, partnerRepo, earningsRepo, offerRepo, routeProvider, trackingUseCase, locationUseCase, deliverySLA, utils.GetEnvDuration("SCHEDULED_DISPATCH_LEAD", 45*time.Minute), utils.GetEnvBool("LOCATION_INTEGRITY_BLOCK_COMPLETION", false))
+	streamUseCase := usecase.NewStreamUseCase(streamHub, deliveryRepo, trackingUseCase)
+	presenceUseCase := usecase.NewPresenceUseCase(partnerRepo, deliveryRepo, usecase.PresenceConfig{
+		StaleAfter:   utils.GetEnvDuration("PRESENCE_STALE_AFTER", usecase.DefaultPresenceConfig.StaleAfter),
+		OfflineAfter: utils.GetEnvDuration("PRESENCE_OFFLINE_AFTER", usecase.DefaultPresenceConfig.OfflineAfter),
+	})
 	earningsUseCase := usecase.NewEarningsUseCase(earningsRepo, deliveryRepo)
+	feedbackUseCase := usecase.NewFeedbackUseCase(feedbackRepo, deliveryRepo, partnerRepo, trackingUseCase, utils.GetEnvDuration("RATING_HALF_LIFE", 90*24*time.Hour))
+	incidentUseCase := usecase.NewIncidentUseCase(incidentRepo, deliveryRepo, supportNotifier)
+	chatUseCase := usecase.NewChatUseCase(chatRepo, deliveryRepo, partnerRepo, trackingUseCase)
+	callUseCase := usecase.NewCallUseCase(deliveryRepo, partnerRepo, callBridge, utils.GetEnvDuration("CALL_BRIDGE_SESSION_TTL", 10*time.Minute))
+	outboxRelay := usecase.NewOutboxRelay(outboxRepo, usecase.EventPublishers{streamHub, webhookUseCase})
+	var dispatcher usecase.Dispatcher = usecase.NewScoringDispatcher(deliveryRepo, partnerRepo, offerRepo, zoneRepo, deliveryUseCase, usecase.DispatchConfig{
+		Strategy:         utils.GetEnv("DISPATCH_STRATEGY", usecase.DispatchStrategyOffer),
+		MaxRadiusKm:      utils.GetEnvFloat("DISPATCH_MAX_RADIUS_KM", 8),
+		MaxActiveOrders:  utils.GetEnvInt("DISPATCH_MAX_ACTIVE_ORDERS", 3),
+		LocationMaxAge:   utils.GetEnvDuration("DISPATCH_LOCATION_MAX_AGE", 10*time.Minute),
+		ScheduleLeadTime: utils.GetEnvDuration("SCHEDULED_DISPATCH_LEAD", 45*time.Minute),
+		Weights:          usecase.DefaultDispatchWeights,
+		OfferFanout:      utils.GetEnvInt("DISPATCH_OFFER_FANOUT", 1),
+		OfferTTL:         utils.GetEnvDuration("DISPATCH_OFFER_TTL", time.Minute),
+		ReofferCooldown:  utils.GetEnvDuration("DISPATCH_REOFFER_COOLDOWN", 10*time.Minute),
+		ZoneNearKm:       utils.GetEnvFloat("DISPATCH_ZONE_NEAR_KM", 2),
+	})
+	zoneUseCase := usecase.NewZoneUseCase(zoneRepo, partnerRepo)
+	fleetUseCase := usecase.NewFleetUseCase(deliveryRepo, partnerRepo, zoneRepo, offerRepo, deliveryUseCase)
+	offerUseCase := usecase.NewOfferUseCase(offerRepo, deliveryRepo, dispatcher)
+	watchdogUseCase := usecase.NewWatchdogUseCase(deliveryRepo, partnerRepo, dispatcher, supportNotifier, usecase.WatchdogConfig{
+		Policy:           utils.GetEnv("WATCHDOG_POLICY", usecase.WatchdogPolicyReassign),
+		OfflineAfter:     utils.GetEnvDuration("WATCHDOG_OFFLINE_AFTER", 10*time.Minute),
+		AssignedTimeout:  utils.GetEnvDuration("WATCHDOG_ASSIGNED_TIMEOUT", 30*time.Minute),
+		InTransitTimeout: utils.GetEnvDuration("WATCHDOG_IN_TRANSIT_TIMEOUT", 90*time.Minute),
+	})
+	slaUseCase := usecase.NewSLAUseCase(
+		deliveryRepo,
+		partnerRepo,
+		routeProvider,
+		deliverySLA,
+		utils.GetEnvDuration("SLA_AT_RISK_BUFFER", 5*time.Minute),
+	)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authUseCase)
 	deliveryHandler := handlers.NewDeliveryHandler(deliveryUseCase)
 	profileHandler := handlers.NewProfileHandler(profileUseCase)
+	locationHandler := handlers.NewLocationHandler(locationUseCase)
+	presenceHandler := handlers.NewPresenceHandler(presenceUseCase)
+	integrityHandler := handlers.NewLocationIntegrityHandler(integrityUseCase)
+	streamHandler := handlers.NewStreamHandler(streamUseCase, utils.GetEnvDuration("STREAM_HEARTBEAT_INTERVAL", 15*time.Second))
 	earningsHandler := handlers.NewEarningsHandler(earningsUseCase)
+	webhookHandler := handlers.NewWebhookHandler(webhookUseCase)
+	slaHandler := handlers.NewSLAHandler(slaUseCase)
+	callHandler := handlers.NewCallHandler(callUseCase)
+	trackingHandler := handlers.NewTrackingHandler(trackingUseCase)
+	feedbackHandler := handlers.NewFeedbackHandler(feedbackUseCase)
+	incidentHandler := handlers.NewIncidentHandler(incidentUseCase)
+	dispatchHandler := handlers.NewDispatchHandler(dispatcher)
+	offerHandler := handlers.NewOfferHandler(offerUseCase)
+	chatHandler := handlers.NewChatHandler(chatUseCase)
+	watchdogHandler := handlers.NewWatchdogHandler(watchdogUseCase)
+	zoneHandler := handlers.NewZoneHandler(zoneUseCase)
+	fleetHandler := handlers.NewFleetHandler(fleetUseCase)
+
+	// Start background workers
+	go outboxRelay.Run(context.Background(), utils.GetEnvDuration("OUTBOX_RELAY_INTERVAL", 2*time.Second))
+	go webhookUseCase.Run(context.Background(), utils.GetEnvDuration("WEBHOOK_DISPATCH_INTERVAL", 5*time.Second))
+	go slaUseCase.Run(context.Background(), utils.GetEnvDuration("SLA_CHECK_INTERVAL", time.Minute))
+	go usecase.RunDispatcher(context.Background(), dispatcher, utils.GetEnvDuration("DISPATCH_INTERVAL", 15*time.Second))
+	go presenceUseCase.Run(context.Background(), utils.GetEnvDuration("PRESENCE_SWEEP_INTERVAL", 30*time.Second))
+	go watchdogUseCase.Run(context.Background(), utils.GetEnvDuration("WATCHDOG_INTERVAL", time.Minute))
+	go earningsUseCase.RunReconciliation(context.Background(), utils.GetEnvDuration("EARNINGS_RECONCILE_INTERVAL", 10*time.Minute))
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
@@ -38,6 +188,15 @@ func SetupRoutes(router *gin.Engine) {
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
+		// Public customer tracking (the token is the credential)
+		v1.GET("/track/:token", trackingHandler.GetTracking)
+		v1.GET("/track/:token/stream", streamHandler.StreamTracking)
+		v1.POST("/track/:token/feedback", feedbackHandler.SubmitCustomerFeedback)
+		v1.GET("/track/:token/messages", chatHandler.GetCustomerMessages)
+		v1.POST("/track/:token/messages", chatHandler.SendCustomerMessage)
+		v1.POST("/track/:token/messages/read", chatHandler.MarkCustomerRead)
+		v1.GET("/track/:token/quick-replies", chatHandler.GetCustomerQuickReplies)
+
 		// Public routes (no authentication required)
 		delivery := v1.Group("/delivery")
 		{
@@ -49,26 +208,117 @@ func SetupRoutes(router *gin.Engine) {
 			// Protected routes (authentication required)
 			protected := delivery.Group("")
 			protected.Use(middlewares.AuthMiddleware())
+			protected.Use(middlewares.IdempotencyMiddleware(idempotencyRepo, utils.GetEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour), utils.GetEnvDuration("IDEMPOTENCY_LEASE", 2*time.Minute)))
 			{
 				// Orders
 				protected.GET("/orders/active", deliveryHandler.GetActiveOrders)
+				protected.GET("/orders/active/route", deliveryHandler.GetActiveRoute)
 				protected.GET("/orders/history", deliveryHandler.GetOrderHistory)
+				protected.GET("/orders/nearby", deliveryHandler.GetNearbyOrders)
+				protected.GET("/orders/slot-adherence", deliveryHandler.GetMySlotAdherence)
 				protected.GET("/orders/:id", deliveryHandler.GetOrderDetails)
 				protected.POST("/orders/:id/accept", deliveryHandler.AcceptOrder)
+				protected.POST("/orders/:id/reject", offerHandler.RejectOrder)
+				protected.POST("/orders/:id/pickup-scan", deliveryHandler.SubmitPickupScan)
 				protected.POST("/orders/:id/status", deliveryHandler.UpdateOrderStatus)
 				protected.POST("/orders/:id/complete", deliveryHandler.CompleteDelivery)
+				protected.POST("/orders/:id/call-customer", callHandler.CallCustomer)
+				protected.POST("/orders/:id/incidents", incidentHandler.ReportIncident)
+				protected.GET("/orders/:id/incidents", incidentHandler.GetDeliveryIncidents)
+				protected.GET("/orders/:id/messages", chatHandler.GetPartnerMessages)
+				protected.POST("/orders/:id/messages", chatHandler.SendPartnerMessage)
+				protected.POST("/orders/:id/messages/read", chatHandler.MarkPartnerRead)
+				protected.GET("/messages/quick-replies", chatHandler.GetPartnerQuickReplies)
+
+				// Offers
+				protected.GET("/offers", offerHandler.GetOffers)
+				protected.GET("/offers/stats", offerHandler.GetMyOfferStats)
 
 				// Profile
 				protected.GET("/profile", profileHandler.GetProfile)
 				protected.PUT("/profile", profileHandler.UpdateProfile)
-				protected.POST("/location", profileHandler.UpdateLocation)
+				protected.POST("/location", locationHandler.UpdateLocation)
+				protected.POST("/location/batch", locationHandler.UploadLocationBatch)
 				protected.POST("/availability", profileHandler.ToggleAvailability)
+				protected.POST("/heartbeat", presenceHandler.Heartbeat)
+				protected.POST("/break", presenceHandler.SetBreak)
 
 				// Earnings
 				protected.GET("/earnings", earningsHandler.GetEarnings)
 				protected.GET("/earnings/history", earningsHandler.GetEarningsHistory)
 			}
 		}
+
+		// Ops routes (warehouse staff, support and internal services)
+		ops := v1.Group("/ops")
+		ops.Use(middlewares.OpsAuthMiddleware())
+		{
+			// Orders
+			ops.POST("/orders", deliveryHandler.CreateDelivery)
+
+			// Pickup handover
+			ops.POST("/orders/:id/confirm-pickup", deliveryHandler.ConfirmPickup)
+
+			// Dispatch
+			ops.POST("/dispatch/run", dispatchHandler.RunDispatch)
+			ops.GET("/orders/:id/dispatch-candidates", dispatchHandler.GetCandidates)
+			ops.GET("/partners/:id/offer-stats", offerHandler.GetPartnerOfferStats)
+			ops.POST("/watchdog/run", watchdogHandler.RunWatchdog)
+
+			// Zones
+			ops.POST("/zones", zoneHandler.CreateZone)
+			ops.GET("/zones", zoneHandler.GetZones)
+			ops.GET("/zones/:id", zoneHandler.GetZone)
+			ops.PUT("/zones/:id", zoneHandler.UpdateZone)
+			ops.PUT("/partners/:id/home-zone", zoneHandler.SetPartnerHomeZone)
+			ops.GET("/serviceability", zoneHandler.CheckServiceability)
+
+			// Fleet console
+			ops.GET("/fleet/partners", fleetHandler.GetPartners)
+			ops.GET("/fleet/orders/unassigned", fleetHandler.GetUnassignedOrders)
+			ops.GET("/fleet/orders/at-risk", fleetHandler.GetAtRiskOrders)
+			ops.POST("/orders/:id/assign", fleetHandler.AssignOrder)
+			ops.POST("/orders/:id/unassign", fleetHandler.UnassignOrder)
+			ops.POST("/orders/:id/reassign", fleetHandler.ReassignOrder)
+
+			// Location history
+			ops.GET("/orders/:id/trail", locationHandler.GetDeliveryTrail)
+			ops.GET("/partners/:id/locations", locationHandler.GetPartnerLocations)
+			ops.GET("/location-flags", integrityHandler.GetLocationFlags)
+			ops.POST("/location-flags/:id/review", integrityHandler.ReviewLocationFlag)
+
+			// Live streams
+			ops.GET("/stream", streamHandler.StreamOps)
+
+			// Customer tracking
+			ops.POST("/orders/:id/tracking-link", trackingHandler.IssueTrackingLink)
+
+			// Conversations
+			ops.GET("/orders/:id/messages", chatHandler.GetSupportMessages)
+			ops.POST("/orders/:id/messages", chatHandler.SendSupportMessage)
+
+			// Incidents
+			ops.GET("/incidents", incidentHandler.GetIncidents)
+			ops.POST("/incidents/:id/acknowledge", incidentHandler.AcknowledgeIncident)
+			ops.POST("/incidents/:id/resolve", incidentHandler.ResolveIncident)
+
+			// Ratings and feedback
+			ops.POST("/orders/:id/feedback", feedbackHandler.SubmitOrderFeedback)
+			ops.GET("/partners/:id/feedback", feedbackHandler.GetPartnerFeedback)
+			ops.POST("/feedback/:id/exclude", feedbackHandler.ExcludeFeedback)
+			ops.POST("/feedback/:id/include", feedbackHandler.IncludeFeedback)
+
+			// SLA
+			ops.GET("/deliveries/sla-alerts", slaHandler.GetSLAAlerts)
+			ops.GET("/reports/slot-adherence", deliveryHandler.GetSlotAdherenceReport)
+
+			// Webhooks
+			ops.POST("/webhooks", webhookHandler.CreateSubscription)
+			ops.GET("/webhooks", webhookHandler.GetSubscriptions)
+			ops.DELETE("/webhooks/:id", webhookHandler.DeleteSubscription)
+			ops.GET("/webhooks/dead-letters", webhookHandler.GetDeadLetters)
+			ops.POST("/webhooks/messages/:id/redeliver", webhookHandler.Redeliver)
+		}
 	}
 }
 
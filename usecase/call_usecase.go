@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/domain/services"
+	"errors"
+	"log"
+	"time"
+)
+
+type CallUseCase struct {
+	deliveryRepo repositories.DeliveryRepository
+	partnerRepo  repositories.DeliveryPartnerRepository
+	callBridge   services.CallBridge
+	sessionTTL   time.Duration
+}
+
+func NewCallUseCase(
+	deliveryRepo repositories.DeliveryRepository,
+	partnerRepo repositories.DeliveryPartnerRepository,
+	callBridge services.CallBridge,
+	sessionTTL time.Duration,
+) *CallUseCase {
+	return &CallUseCase{
+		deliveryRepo: deliveryRepo,
+		partnerRepo:  partnerRepo,
+		callBridge:   callBridge,
+		sessionTTL:   sessionTTL,
+	}
+}
+
+// CallCustomer opens a short-lived bridge session between the partner and the
+// customer. Every attempt, successful or not, is logged on the delivery.
+func (uc *CallUseCase) CallCustomer(deliveryID, partnerID string) (*entities.CallCustomerResponse, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.CallCustomerResponse{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if delivery.PartnerID != partnerID {
+		return &entities.CallCustomerResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, errors.New("unauthorized")
+	}
+
+	switch delivery.Status {
+	case "assigned", "picked_up", "in_transit":
+	default:
+		return &entities.CallCustomerResponse{
+			Success: false,
+			Message: "Calls are only available while the order is active",
+		}, nil
+	}
+
+	partner, err := uc.partnerRepo.FindByID(partnerID)
+	if err != nil {
+		return &entities.CallCustomerResponse{
+			Success: false,
+			Error:   "Partner not found",
+		}, err
+	}
+
+	attempt := &entities.CallAttempt{
+		PartnerID:   partnerID,
+		Provider:    uc.callBridge.Name(),
+		AttemptedAt: time.Now(),
+	}
+
+	session, bridgeErr := uc.callBridge.CreateSession(&entities.CallBridgeRequest{
+		DeliveryID:  deliveryID,
+		CallerPhone: partner.PhoneNumber,
+		CalleePhone: delivery.CustomerPhone,
+		TTL:         uc.sessionTTL,
+	})
+	if bridgeErr != nil {
+		attempt.Status = "failed"
+		attempt.Error = bridgeErr.Error()
+	} else {
+		attempt.Status = "initiated"
+		attempt.SessionID = session.SessionID
+		attempt.ExpiresAt = session.ExpiresAt
+	}
+
+	if err := uc.deliveryRepo.AddCallAttempt(deliveryID, attempt); err != nil {
+		log.Printf("❌ Failed to log call attempt for delivery %s: %v", deliveryID, err)
+	}
+
+	if bridgeErr != nil {
+		return &entities.CallCustomerResponse{
+			Success: false,
+			Error:   "Failed to connect call",
+		}, bridgeErr
+	}
+
+	return &entities.CallCustomerResponse{
+		Success:     true,
+		Message:     "Dial the number to reach the customer",
+		ProxyNumber: session.ProxyNumber,
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"fmt"
+	"math"
+)
+
+// vehicleCapacity is what a partner can carry at once across their batch
+type vehicleCapacity struct {
+	MaxWeightKg     float64
+	MaxVolumeLitres float64
+	MaxSizeClass    string // largest single order the vehicle takes
+}
+
+var vehicleCapacities = map[string]vehicleCapacity{
+	"bike":    {MaxWeightKg: 12, MaxVolumeLitres: 40, MaxSizeClass: "medium"},
+	"scooter": {MaxWeightKg: 25, MaxVolumeLitres: 80, MaxSizeClass: "large"},
+	"car":     {MaxWeightKg: 150, MaxVolumeLitres: 600, MaxSizeClass: "xl"},
+}
+
+// Partners who never set a vehicle are treated as riding a scooter
+var defaultVehicleCapacity = vehicleCapacities["scooter"]
+
+// Size classes from smallest to largest, with the most an order of that class
+// may weigh and occupy
+var sizeClasses = []struct {
+	Name            string
+	MaxWeightKg     float64
+	MaxVolumeLitres float64
+}{
+	{"small", 3, 10},
+	{"medium", 10, 30},
+	{"large", 25, 80},
+	{"xl", math.Inf(1), math.Inf(1)},
+}
+
+const (
+	// Used for items the order service sent without weight or volume
+	defaultItemWeightKg     = 0.5
+	defaultItemVolumeLitres = 1.0
+)
+
+func capacityFor(vehicleType string) vehicleCapacity {
+	if capacity, ok := vehicleCapacities[vehicleType]; ok {
+		return capacity
+	}
+	return defaultVehicleCapacity
+}
+
+// applyLoad derives the weight, volume and size class of the delivery from its items
+func applyLoad(delivery *entities.Delivery) {
+	weight, volume := 0.0, 0.0
+	if len(delivery.Items) == 0 {
+		weight = float64(delivery.ItemsCount) * defaultItemWeightKg
+		volume = float64(delivery.ItemsCount) * defaultItemVolumeLitres
+	}
+	for _, item := range delivery.Items {
+		itemWeight, itemVolume := item.WeightKg, item.VolumeLitres
+		if itemWeight <= 0 {
+			itemWeight = defaultItemWeightKg
+		}
+		if itemVolume <= 0 {
+			itemVolume = defaultItemVolumeLitres
+		}
+		weight += itemWeight * float64(item.Quantity)
+		volume += itemVolume * float64(item.Quantity)
+	}
+
+	delivery.WeightKg = math.Round(weight*100) / 100
+	delivery.VolumeLitres = math.Round(volume*100) / 100
+	delivery.SizeClass = sizeClassFor(delivery.WeightKg, delivery.VolumeLitres)
+}
+
+// deliveryLoad returns the stored load, deriving it for orders written before
+// it was recorded
+func deliveryLoad(delivery *entities.Delivery) (float64, float64, string) {
+	if delivery.SizeClass == "" {
+		applyLoad(delivery)
+	}
+	return delivery.WeightKg, delivery.VolumeLitres, delivery.SizeClass
+}
+
+func sizeClassFor(weightKg, volumeLitres float64) string {
+	for _, class := range sizeClasses {
+		if weightKg <= class.MaxWeightKg && volumeLitres <= class.MaxVolumeLitres {
+			return class.Name
+		}
+	}
+	return sizeClasses[len(sizeClasses)-1].Name
+}
+
+func sizeRank(sizeClass string) int {
+	for i, class := range sizeClasses {
+		if class.Name == sizeClass {
+			return i
+		}
+	}
+	return 0
+}
+
+// vehicleTakes reports whether the vehicle can carry an order of this size at all
+func vehicleTakes(vehicleType, sizeClass string) bool {
+	return sizeRank(sizeClass) <= sizeRank(capacityFor(vehicleType).MaxSizeClass)
+}
+
+// capacityShortfall explains why the order does not fit on top of the orders
+// the partner already carries, or returns "" if it does
+func capacityShortfall(vehicleType string, delivery *entities.Delivery, batch []entities.Delivery) string {
+	capacity := capacityFor(vehicleType)
+	weight, volume, size := deliveryLoad(delivery)
+
+	if !vehicleTakes(vehicleType, size) {
+		vehicle := "your vehicle"
+		if vehicleType != "" {
+			vehicle = "a " + vehicleType
+		}
+		return fmt.Sprintf("This %s order is too large for %s", size, vehicle)
+	}
+
+	for i := range batch {
+		if batch[i].DeliveryID == delivery.DeliveryID {
+			continue
+		}
+		w, v, _ := deliveryLoad(&batch[i])
+		weight += w
+		volume += v
+	}
+
+	if weight > capacity.MaxWeightKg {
+		return fmt.Sprintf("Not enough capacity: this order brings your load to %.1f kg, the limit is %.0f kg", weight, capacity.MaxWeightKg)
+	}
+	if volume > capacity.MaxVolumeLitres {
+		return fmt.Sprintf("Not enough capacity: this order brings your load to %.0f litres, the limit is %.0f litres", volume, capacity.MaxVolumeLitres)
+	}
+	return ""
+}
+
+// heldOrders drops the orders only offered to the partner; they don't take up
+// room until accepted
+func heldOrders(active []entities.Delivery) []entities.Delivery {
+	batch := make([]entities.Delivery, 0, len(active))
+	for _, d := range active {
+		if d.Status != "pending" {
+			batch = append(batch, d)
+		}
+	}
+	return batch
+}
+
+// capacityCheck is what the repository checks the order against when it is assigned
+func capacityCheck(vehicleType string, delivery *entities.Delivery) *entities.CapacityCheck {
+	capacity := capacityFor(vehicleType)
+	weight, volume, _ := deliveryLoad(delivery)
+	return &entities.CapacityCheck{
+		WeightKg:        weight,
+		VolumeLitres:    volume,
+		MaxWeightKg:     capacity.MaxWeightKg,
+		MaxVolumeLitres: capacity.MaxVolumeLitres,
+	}
+}
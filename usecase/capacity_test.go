@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"strings"
+	"testing"
+)
+
+func TestCapacityShortfall(t *testing.T) {
+	tests := []struct {
+		name        string
+		vehicleType string
+		weightKg    float64
+		batch       []entities.Delivery
+		want        string // substring of the shortfall, "" if it fits
+	}{
+		{"fits", "bike", 5, nil, ""},
+		{"too large for the vehicle", "bike", 20, nil, "too large for a bike"},
+		{"too large for an unknown vehicle", "", 30, nil, "too large for your vehicle"},
+		{"over weight with the batch", "bike", 5, []entities.Delivery{testDelivery("held", 8)}, "limit is 12 kg"},
+		{"fits with the batch", "scooter", 5, []entities.Delivery{testDelivery("held", 8)}, ""},
+		// Re-checking an order the partner already holds doesn't count it twice
+		{"order already in the batch", "bike", 7, []entities.Delivery{testDelivery("d1", 7)}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := testDelivery("d1", tt.weightKg)
+			got := capacityShortfall(tt.vehicleType, &delivery, tt.batch)
+			if tt.want == "" && got != "" {
+				t.Errorf("capacityShortfall = %q, want none", got)
+			}
+			if tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Errorf("capacityShortfall = %q, want it to mention %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeldOrdersLeavesOutOffers(t *testing.T) {
+	offered := testDelivery("offered", 5)
+	offered.PartnerID = "p1"
+	active := []entities.Delivery{offered, heldBy("held", "p1", 5)}
+
+	batch := heldOrders(active)
+	if len(batch) != 1 || batch[0].DeliveryID != "held" {
+		t.Errorf("heldOrders = %v, want only held", batch)
+	}
+}
@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	chatPageSize     = 100
+	chatPollInterval = time.Second
+)
+
+// quickReplies are the canned messages offered to each participant role
+var quickReplies = map[string][]entities.QuickReply{
+	"partner": {
+		{TemplateID: "at_gate", Body: "I'm at the gate"},
+		{TemplateID: "outside_building", Body: "I'm outside your building"},
+		{TemplateID: "arriving_soon", Body: "I'll be there in about 5 minutes"},
+		{TemplateID: "running_late", Body: "Running a few minutes late, sorry!"},
+		{TemplateID: "need_landmark", Body: "Could you share a landmark near you?"},
+		{TemplateID: "cannot_find_address", Body: "I can't find the address, please guide me"},
+	},
+	"customer": {
+		{TemplateID: "coming_down", Body: "Coming down now"},
+		{TemplateID: "leave_at_door", Body: "Please leave it at the door"},
+		{TemplateID: "hand_to_security", Body: "Please hand it to security"},
+		{TemplateID: "wait_two_minutes", Body: "Please wait 2 minutes"},
+	},
+	"support": {
+		{TemplateID: "looking_into_it", Body: "Support here, we're looking into this"},
+		{TemplateID: "resolved", Body: "This has been sorted out, thanks for your patience"},
+	},
+}
+
+type ChatUseCase struct {
+	chatRepo        repositories.ChatRepository
+	deliveryRepo    repositories.DeliveryRepository
+	partnerRepo     repositories.DeliveryPartnerRepository
+	trackingUseCase *TrackingUseCase
+}
+
+func NewChatUseCase(
+	chatRepo repositories.ChatRepository,
+	deliveryRepo repositories.DeliveryRepository,
+	partnerRepo repositories.DeliveryPartnerRepository,
+	trackingUseCase *TrackingUseCase,
+) *ChatUseCase {
+	return &ChatUseCase{
+		chatRepo:        chatRepo,
+		deliveryRepo:    deliveryRepo,
+		partnerRepo:     partnerRepo,
+		trackingUseCase: trackingUseCase,
+	}
+}
+
+// chatParticipant is whoever is reading or writing the thread
+type chatParticipant struct {
+	delivery *entities.Delivery
+	role     string
+	id       string
+	name     string
+}
+
+func (uc *ChatUseCase) partnerParticipant(deliveryID, partnerID string) (*chatParticipant, string, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return nil, "Order not found", err
+	}
+
+	if delivery.PartnerID != partnerID {
+		return nil, "Unauthorized", errors.New("unauthorized")
+	}
+
+	name := "Delivery partner"
+	if partner, err := uc.partnerRepo.FindByID(partnerID); err == nil && partner.Name != "" {
+		name = firstName(partner.Name)
+	}
+
+	return &chatParticipant{delivery: delivery, role: "partner", id: partnerID, name: name}, "", nil
+}
+
+// customerParticipant returns a nil participant and no error for an unknown
+// or expired tracking token
+func (uc *ChatUseCase) customerParticipant(token string) (*chatParticipant, string, error) {
+	delivery, err := uc.trackingUseCase.ResolveToken(token)
+	if err != nil {
+		return nil, "Failed to load order", err
+	}
+
+	if delivery == nil {
+		return nil, "Tracking link is invalid or has expired", nil
+	}
+
+	name := "Customer"
+	if delivery.CustomerName != "" {
+		name = firstName(delivery.CustomerName)
+	}
+
+	return &chatParticipant{delivery: delivery, role: "customer", id: delivery.CustomerID, name: name}, "", nil
+}
+
+func (uc *ChatUseCase) supportParticipant(deliveryID, actor string) (*chatParticipant, string, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return nil, "Order not found", err
+	}
+
+	return &chatParticipant{delivery: delivery, role: "support", id: actor, name: "Support"}, "", nil
+}
+
+// Partner
+
+func (uc *ChatUseCase) GetPartnerMessages(ctx context.Context, deliveryID, partnerID string, req *entities.GetMessagesRequest) (*entities.GetMessagesResponse, error) {
+	p, errMsg, err := uc.partnerParticipant(deliveryID, partnerID)
+	if p == nil {
+		return &entities.GetMessagesResponse{Success: false, Error: errMsg}, err
+	}
+	return uc.getMessages(ctx, p, req)
+}
+
+func (uc *ChatUseCase) SendPartnerMessage(deliveryID, partnerID string, req *entities.SendMessageRequest) (*entities.SendMessageResponse, error) {
+	p, errMsg, err := uc.partnerParticipant(deliveryID, partnerID)
+	if p == nil {
+		return &entities.SendMessageResponse{Success: false, Error: errMsg}, err
+	}
+	return uc.sendMessage(p, req)
+}
+
+func (uc *ChatUseCase) MarkPartnerRead(deliveryID, partnerID string) (*entities.ResponseMessage, error) {
+	p, errMsg, err := uc.partnerParticipant(deliveryID, partnerID)
+	if p == nil {
+		return &entities.ResponseMessage{Success: false, Error: errMsg}, err
+	}
+	return uc.markRead(p)
+}
+
+// Customer
+
+func (uc *ChatUseCase) GetCustomerMessages(ctx context.Context, token string, req *entities.GetMessagesRequest) (*entities.GetMessagesResponse, error) {
+	p, errMsg, err := uc.customerParticipant(token)
+	if p == nil {
+		return &entities.GetMessagesResponse{Success: false, Error: errMsg}, err
+	}
+	return uc.getMessages(ctx, p, req)
+}
+
+func (uc *ChatUseCase) SendCustomerMessage(token string, req *entities.SendMessageRequest) (*entities.SendMessageResponse, error) {
+	p, errMsg, err := uc.customerParticipant(token)
+	if p == nil {
+		return &entities.SendMessageResponse{Success: false, Error: errMsg}, err
+	}
+	return uc.sendMessage(p, req)
+}
+
+func (uc *ChatUseCase) MarkCustomerRead(token string) (*entities.ResponseMessage, error) {
+	p, errMsg, err := uc.customerParticipant(token)
+	if p == nil {
+		return &entities.ResponseMessage{Success: false, Error: errMsg}, err
+	}
+	return uc.markRead(p)
+}
+
+// Support
+
+func (uc *ChatUseCase) GetSupportMessages(ctx context.Context, deliveryID string, req *entities.GetMessagesRequest) (*entities.GetMessagesResponse, error) {
+	p, errMsg, err := uc.supportParticipant(deliveryID, "")
+	if p == nil {
+		return &entities.GetMessagesResponse{Success: false, Error: errMsg}, err
+	}
+	return uc.getMessages(ctx, p, req)
+}
+
+func (uc *ChatUseCase) SendSupportMessage(deliveryID, actor string, req *entities.SendMessageRequest) (*entities.SendMessageResponse, error) {
+	p, errMsg, err := uc.supportParticipant(deliveryID, actor)
+	if p == nil {
+		return &entities.SendMessageResponse{Success: false, Error: errMsg}, err
+	}
+	return uc.sendMessage(p, req)
+}
+
+func (uc *ChatUseCase) GetQuickReplies(role string) *entities.GetQuickRepliesResponse {
+	replies := quickReplies[role]
+	if replies == nil {
+		replies = []entities.QuickReply{}
+	}
+
+	return &entities.GetQuickRepliesResponse{
+		Success:      true,
+		QuickReplies: replies,
+	}
+}
+
+// getMessages long-polls: when nothing newer than req.After exists it waits
+// up to req.Wait seconds for a message to arrive
+func (uc *ChatUseCase) getMessages(ctx context.Context, p *chatParticipant, req *entities.GetMessagesRequest) (*entities.GetMessagesResponse, error) {
+	deadline := time.Now().Add(time.Duration(req.Wait) * time.Second)
+
+	var messages []entities.ChatMessage
+	for {
+		var err error
+		messages, err = uc.chatRepo.GetMessages(p.delivery.DeliveryID, req.After, chatPageSize)
+		if err != nil {
+			return &entities.GetMessagesResponse{
+				Success: false,
+				Error:   "Failed to load messages",
+			}, err
+		}
+
+		if len(messages) > 0 || !time.Now().Before(deadline) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return &entities.GetMessagesResponse{
+				Success:    true,
+				Messages:   []entities.ChatMessage{},
+				IsReadOnly: isChatClosed(p.delivery),
+			}, nil
+		case <-time.After(chatPollInterval):
+		}
+	}
+
+	if messages == nil {
+		messages = []entities.ChatMessage{}
+	}
+
+	unread, err := uc.chatRepo.CountUnread(p.delivery.DeliveryID, p.role)
+	if err != nil {
+		return &entities.GetMessagesResponse{
+			Success: false,
+			Error:   "Failed to load messages",
+		}, err
+	}
+
+	return &entities.GetMessagesResponse{
+		Success:     true,
+		Messages:    messages,
+		UnreadCount: unread,
+		IsReadOnly:  isChatClosed(p.delivery),
+	}, nil
+}
+
+func (uc *ChatUseCase) sendMessage(p *chatParticipant, req *entities.SendMessageRequest) (*entities.SendMessageResponse, error) {
+	if isChatClosed(p.delivery) {
+		return &entities.SendMessageResponse{
+			Success: false,
+			Message: "This conversation is closed",
+		}, nil
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if req.TemplateID != "" {
+		reply, ok := findQuickReply(p.role, req.TemplateID)
+		if !ok {
+			return &entities.SendMessageResponse{
+				Success: false,
+				Message: "Unknown quick reply",
+			}, nil
+		}
+		body = reply.Body
+	}
+
+	if body == "" {
+		return &entities.SendMessageResponse{
+			Success: false,
+			Message: "Message cannot be empty",
+		}, nil
+	}
+
+	message := &entities.ChatMessage{
+		DeliveryID: p.delivery.DeliveryID,
+		SenderRole: p.role,
+		SenderID:   p.id,
+		SenderName: p.name,
+		Body:       body,
+		TemplateID: req.TemplateID,
+	}
+
+	if err := uc.chatRepo.Create(message); err != nil {
+		return &entities.SendMessageResponse{
+			Success: false,
+			Error:   "Failed to send message",
+		}, err
+	}
+
+	return &entities.SendMessageResponse{
+		Success: true,
+		Chat:    message,
+	}, nil
+}
+
+func (uc *ChatUseCase) markRead(p *chatParticipant) (*entities.ResponseMessage, error) {
+	if _, err := uc.chatRepo.MarkRead(p.delivery.DeliveryID, p.role, time.Now()); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to mark messages as read",
+		}, err
+	}
+
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: "Messages marked as read",
+	}, nil
+}
+
+func findQuickReply(role, templateID string) (entities.QuickReply, bool) {
+	for _, reply := range quickReplies[role] {
+		if reply.TemplateID == templateID {
+			return reply, true
+		}
+	}
+	return entities.QuickReply{}, false
+}
+
+// isChatClosed reports whether the thread is read-only
+func isChatClosed(delivery *entities.Delivery) bool {
+	return delivery.Status == "delivered" || delivery.Status == "cancelled"
+}
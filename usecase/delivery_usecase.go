@@ -3,7 +3,13 @@ package usecase
 import (
 	"deliveryAppBackend/domain/entities"
 	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/domain/services"
+	"deliveryAppBackend/utils"
 	"errors"
+	"log"
+	"math"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -11,17 +17,42 @@ type DeliveryUseCase struct {
 	deliveryRepo repositories.DeliveryRepository
 	partnerRepo  repositories.DeliveryPartnerRepository
 	earningsRepo repositories.EarningsRepository
+	offerRepo    repositories.OfferRepository
+	routes       services.RouteProvider
+	trackingUseCase *TrackingUseCase
+	// Coordinates sent with status changes go through the same checks as pings
+	locationUseCase *LocationUseCase
+	// Deliver-now orders are due this long after they come in
+	sla time.Duration
+	// How long before a scheduled window opens the order is released for pickup
+	scheduleLeadTime time.Duration
+	// Hold completion while location integrity flags on the order are open
+	blockFlaggedCompletion bool
 }
 
 func NewDeliveryUseCase(
 	deliveryRepo repositories.DeliveryRepository,
 	partnerRepo repositories.DeliveryPartnerRepository,
 	earningsRepo repositories.EarningsRepository,
+	offerRepo repositories.OfferRepository,
+	routes services.RouteProvider,
+	trackingUseCase *TrackingUseCase,
+	locationUseCase *LocationUseCase,
+	sla time.Duration,
+	scheduleLeadTime time.Duration,
+	blockFlaggedCompletion bool,
 ) *DeliveryUseCase {
 	return &DeliveryUseCase{
-		deliveryRepo: deliveryRepo,
-		partnerRepo:  partnerRepo,
-		earningsRepo: earningsRepo,
+		deliveryRepo:           deliveryRepo,
+		partnerRepo:            partnerRepo,
+		earningsRepo:           earningsRepo,
+		offerRepo:              offerRepo,
+		routes:                 routes,
+		trackingUseCase:        trackingUseCase,
+		locationUseCase:        locationUseCase,
+		sla:                    sla,
+		scheduleLeadTime:       scheduleLeadTime,
+		blockFlaggedCompletion: blockFlaggedCompletion,
 	}
 }
 
@@ -33,6 +64,11 @@ func (uc *DeliveryUseCase) GetActiveOrders(partnerID string) (*entities.GetActiv
 		}, err
 	}
 
+	// Most urgent first: scheduled orders by window start, the rest by creation time
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return dueFrom(&deliveries[i]).Before(dueFrom(&deliveries[j]))
+	})
+
 	orders := make([]entities.DeliveryListItem, 0, len(deliveries))
 	for _, d := range deliveries {
 		orders = append(orders, entities.DeliveryListItem{
@@ -44,6 +80,10 @@ func (uc *DeliveryUseCase) GetActiveOrders(partnerID string) (*entities.GetActiv
 			DeliveryFee: d.DeliveryFee,
 			ItemsCount:  d.ItemsCount,
 			Distance:    d.Distance,
+			ETA:         d.ETA,
+			SLAStatus:   d.SLAStatus,
+			WindowStart: d.WindowStart,
+			WindowEnd:   d.WindowEnd,
 			CreatedAt:   d.CreatedAt,
 		})
 	}
@@ -55,6 +95,186 @@ func (uc *DeliveryUseCase) GetActiveOrders(partnerID string) (*entities.GetActiv
 	}, nil
 }
 
+// GetActiveRoute plans the order in which the partner should work through
+// their active orders, starting from their current location
+func (uc *DeliveryUseCase) GetActiveRoute(partnerID string) (*entities.GetActiveRouteResponse, error) {
+	partner, err := uc.partnerRepo.FindByID(partnerID)
+	if err != nil {
+		return &entities.GetActiveRouteResponse{
+			Success: false,
+			Error:   "Partner not found",
+		}, err
+	}
+
+	if partner.CurrentLatitude == 0 && partner.CurrentLongitude == 0 {
+		return &entities.GetActiveRouteResponse{
+			Success: true,
+			Message: "Share your location to plan your route",
+			Stops:   []entities.RouteStop{},
+		}, nil
+	}
+
+	deliveries, err := uc.deliveryRepo.GetActiveOrdersByPartner(partnerID)
+	if err != nil {
+		return &entities.GetActiveRouteResponse{
+			Success: false,
+			Error:   "Failed to load active orders",
+		}, err
+	}
+
+	// Orders not picked up yet need a warehouse stop before the drop; pending
+	// orders have not been accepted and are left out
+	points := []entities.Coordinate{partnerCoordinate(partner)}
+	var stops []plannedStop
+	for i := range deliveries {
+		d := &deliveries[i]
+		pickup := -1
+		switch d.Status {
+		case "assigned":
+			pickup = len(stops)
+			stops = append(stops, plannedStop{delivery: d, kind: "pickup", point: len(points), pickup: -1})
+			points = append(points, pickupCoordinate(d))
+		case "picked_up", "in_transit":
+		default:
+			continue
+		}
+		stops = append(stops, plannedStop{delivery: d, kind: "drop", point: len(points), pickup: pickup})
+		points = append(points, dropCoordinate(d))
+	}
+
+	if len(stops) == 0 {
+		return &entities.GetActiveRouteResponse{
+			Success: true,
+			Stops:   []entities.RouteStop{},
+		}, nil
+	}
+
+	matrix, err := uc.routes.Matrix(points)
+	if err != nil {
+		return &entities.GetActiveRouteResponse{
+			Success: false,
+			Error:   "Failed to plan route",
+		}, err
+	}
+
+	planner := &routePlanner{
+		stops:    stops,
+		matrix:   matrix,
+		speedKmh: averageSpeedKmh(partner.VehicleType),
+		start:    time.Now(),
+	}
+	order, schedule, finishAt := planner.plan()
+
+	routeStops := make([]entities.RouteStop, 0, len(order))
+	totalKm := 0.0
+	for pos, i := range order {
+		stop := stops[i]
+		d := stop.delivery
+		point := points[stop.point]
+		address := d.DeliveryAddress
+		if stop.kind == "pickup" {
+			address = d.PickupAddress
+		}
+
+		totalKm += schedule[pos].legKm
+		routeStops = append(routeStops, entities.RouteStop{
+			Sequence:    pos + 1,
+			Type:        stop.kind,
+			DeliveryID:  d.DeliveryID,
+			OrderID:     d.OrderID,
+			Address:     address,
+			Latitude:    point.Latitude,
+			Longitude:   point.Longitude,
+			LegDistance: math.Round(schedule[pos].legKm*100) / 100,
+			ArrivalAt:   schedule[pos].arrival,
+			WaitMinutes: int(schedule[pos].wait.Minutes()),
+			LateMinutes: int(schedule[pos].late.Minutes()),
+			WindowStart: d.WindowStart,
+			WindowEnd:   d.WindowEnd,
+		})
+	}
+
+	return &entities.GetActiveRouteResponse{
+		Success:       true,
+		Stops:         routeStops,
+		Count:         len(routeStops),
+		TotalDistance: math.Round(totalKm*100) / 100,
+		FinishAt:      &finishAt,
+		RouteSource:   matrix.Source,
+	}, nil
+}
+
+// GetNearbyOrders lists pending orders around the partner's last known
+// location that they could accept right now, closest pickup first
+func (uc *DeliveryUseCase) GetNearbyOrders(partnerID string, req *entities.GetNearbyOrdersRequest) (*entities.GetNearbyOrdersResponse, error) {
+	partner, err := uc.partnerRepo.FindByID(partnerID)
+	if err != nil {
+		return &entities.GetNearbyOrdersResponse{
+			Success: false,
+		}, err
+	}
+
+	if partner.Location == nil {
+		return &entities.GetNearbyOrdersResponse{
+			Success:  true,
+			Message:  "Share your location to see nearby orders",
+			RadiusKm: req.RadiusKm,
+			Orders:   []entities.DeliveryListItem{},
+		}, nil
+	}
+
+	deliveries, err := uc.deliveryRepo.FindPendingOrdersNear(*partner.Location, req.RadiusKm, req.Limit)
+	if err != nil {
+		return &entities.GetNearbyOrdersResponse{
+			Success: false,
+		}, err
+	}
+
+	ids := make([]string, 0, len(deliveries))
+	for _, d := range deliveries {
+		ids = append(ids, d.DeliveryID)
+	}
+	offered, err := uc.offerRepo.GetDeliveriesWithPendingOffers(ids)
+	if err != nil {
+		return &entities.GetNearbyOrdersResponse{
+			Success: false,
+		}, err
+	}
+
+	now := time.Now()
+	orders := make([]entities.DeliveryListItem, 0, len(deliveries))
+	for _, d := range deliveries {
+		// Orders out on offer to someone else, or not yet released, can't be accepted
+		if offered[d.DeliveryID] {
+			continue
+		}
+		if releaseAt := uc.releaseTime(&d); releaseAt != nil && now.Before(*releaseAt) {
+			continue
+		}
+		orders = append(orders, entities.DeliveryListItem{
+			ID:             d.DeliveryID,
+			OrderID:        d.OrderID,
+			Status:         d.Status,
+			Address:        d.DeliveryAddress,
+			Amount:         d.OrderAmount,
+			DeliveryFee:    d.DeliveryFee,
+			ItemsCount:     d.ItemsCount,
+			Distance:       d.Distance,
+			PickupDistance: utils.HaversineKm(partner.Location.Latitude(), partner.Location.Longitude(), d.PickupLatitude, d.PickupLongitude),
+			WindowStart:    d.WindowStart,
+			WindowEnd:      d.WindowEnd,
+			CreatedAt:      d.CreatedAt,
+		})
+	}
+
+	return &entities.GetNearbyOrdersResponse{
+		Success:  true,
+		RadiusKm: req.RadiusKm,
+		Orders:   orders,
+		Count:    len(orders),
+	}, nil
+}
+
 func (uc *DeliveryUseCase) GetOrderHistory(partnerID string, req *entities.GetOrderHistoryRequest) (*entities.GetOrderHistoryResponse, error) {
 	deliveries, total, err := uc.deliveryRepo.GetOrderHistory(partnerID, req.Limit, req.Offset)
 	if err != nil {
@@ -74,6 +294,10 @@ func (uc *DeliveryUseCase) GetOrderHistory(partnerID string, req *entities.GetOr
 			DeliveryFee: d.DeliveryFee,
 			ItemsCount:  d.ItemsCount,
 			Distance:    d.Distance,
+			ETA:         d.ETA,
+			SLAStatus:   d.SLAStatus,
+			WindowStart: d.WindowStart,
+			WindowEnd:   d.WindowEnd,
 			CreatedAt:   d.CreatedAt,
 		})
 	}
@@ -100,12 +324,113 @@ func (uc *DeliveryUseCase) GetOrderDetails(deliveryID string) (*entities.GetOrde
 		}, err
 	}
 
+	// Partners reach the customer through the call bridge, never directly
+	delivery.CustomerPhone = utils.MaskPhone(delivery.CustomerPhone)
+	deliveryLoad(delivery)
+
 	return &entities.GetOrderDetailsResponse{
 		Success: true,
 		Order:   delivery,
 	}, nil
 }
 
+// CreateDelivery registers an order coming from the order service. The
+// pickup to drop distance and duration come from the route provider; if it
+// fails the SLA checker fills them in on its next pass.
+func (uc *DeliveryUseCase) CreateDelivery(req *entities.CreateDeliveryRequest) (*entities.CreateDeliveryResponse, error) {
+	if (req.WindowStart == nil) != (req.WindowEnd == nil) {
+		return &entities.CreateDeliveryResponse{
+			Success: false,
+			Message: "windowStart and windowEnd must be set together",
+		}, nil
+	}
+	if req.WindowStart != nil && !req.WindowEnd.After(*req.WindowStart) {
+		return &entities.CreateDeliveryResponse{
+			Success: false,
+			Message: "windowEnd must be after windowStart",
+		}, nil
+	}
+
+	existing, err := uc.deliveryRepo.GetByOrderID(req.OrderID)
+	if err != nil {
+		return &entities.CreateDeliveryResponse{
+			Success: false,
+			Error:   "Failed to create delivery",
+		}, err
+	}
+	if existing != nil {
+		return &entities.CreateDeliveryResponse{
+			Success: false,
+			Message: "A delivery already exists for this order",
+			Order:   existing,
+		}, nil
+	}
+
+	itemsCount := 0
+	for _, item := range req.Items {
+		itemsCount += item.Quantity
+	}
+
+	delivery := &entities.Delivery{
+		OrderID:           req.OrderID,
+		CustomerID:        req.CustomerID,
+		CustomerName:      req.CustomerName,
+		CustomerPhone:     req.CustomerPhone,
+		WarehouseID:       req.WarehouseID,
+		Status:            "pending",
+		PickupAddress:     req.PickupAddress,
+		DeliveryAddress:   req.DeliveryAddress,
+		PickupLatitude:    req.PickupLatitude,
+		PickupLongitude:   req.PickupLongitude,
+		DeliveryLatitude:  req.DeliveryLatitude,
+		DeliveryLongitude: req.DeliveryLongitude,
+		OrderAmount:       req.OrderAmount,
+		DeliveryFee:       req.DeliveryFee,
+		ItemsCount:        itemsCount,
+		Items:             req.Items,
+		PaymentMethod:     req.PaymentMethod,
+		Notes:             req.Notes,
+		WindowStart:       req.WindowStart,
+		WindowEnd:         req.WindowEnd,
+	}
+
+	deadline := slaDeadline(time.Now(), req.WindowEnd, uc.sla)
+	delivery.SLADeadline = &deadline
+
+	applyLoad(delivery)
+	if route, err := deliveryRoute(uc.routes, delivery); err != nil {
+		log.Printf("⚠️  Failed to route order %s: %v", req.OrderID, err)
+	} else {
+		applyRoute(delivery, route)
+	}
+
+	err = uc.deliveryRepo.Create(delivery)
+	if errors.Is(err, repositories.ErrDeliveryExists) {
+		// A concurrent create for the same order won the race
+		existing, err := uc.deliveryRepo.GetByOrderID(req.OrderID)
+		if err != nil {
+			log.Printf("⚠️  Failed to load existing delivery for order %s: %v", req.OrderID, err)
+		}
+		return &entities.CreateDeliveryResponse{
+			Success: false,
+			Message: "A delivery already exists for this order",
+			Order:   existing,
+		}, nil
+	}
+	if err != nil {
+		return &entities.CreateDeliveryResponse{
+			Success: false,
+			Error:   "Failed to create delivery",
+		}, err
+	}
+
+	return &entities.CreateDeliveryResponse{
+		Success: true,
+		Message: "Delivery created",
+		Order:   delivery,
+	}, nil
+}
+
 func (uc *DeliveryUseCase) AcceptOrder(deliveryID, partnerID string) (*entities.ResponseMessage, error) {
 	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
 	if err != nil {
@@ -122,16 +447,380 @@ func (uc *DeliveryUseCase) AcceptOrder(deliveryID, partnerID string) (*entities.
 		}, nil
 	}
 
-	if err := uc.deliveryRepo.AcceptOrder(deliveryID, partnerID); err != nil {
+	// An order offered to someone else can only be accepted by them
+	if delivery.PartnerID != "" && delivery.PartnerID != partnerID {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Order is not available",
+		}, nil
+	}
+
+	if releaseAt := uc.releaseTime(delivery); releaseAt != nil && time.Now().Before(*releaseAt) {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Scheduled order. It can be accepted from " + releaseAt.Format(time.RFC3339),
+		}, nil
+	}
+
+	offers, err := uc.offerRepo.GetPendingByDelivery(deliveryID)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to check offers",
+		}, err
+	}
+
+	// While the order is on offer only the partners it was offered to can take it
+	var offer *entities.Offer
+	for i := range offers {
+		if offers[i].PartnerID == partnerID {
+			offer = &offers[i]
+		}
+	}
+	if len(offers) > 0 && offer == nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Order is not available",
+		}, nil
+	}
+	if offer != nil && time.Now().After(offer.ExpiresAt) {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "This offer has expired",
+		}, nil
+	}
+
+	partner, err := uc.partnerRepo.FindByID(partnerID)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to check vehicle capacity",
+		}, err
+	}
+
+	shortfall, err := uc.CheckCapacity(delivery, partner)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to check vehicle capacity",
+		}, err
+	}
+	if shortfall != "" {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: shortfall,
+		}, nil
+	}
+
+	err = uc.assignOrder(delivery, partnerID, capacityCheck(partner.VehicleType, delivery), &entities.TimelineEntry{Actor: "partner"})
+	if errors.Is(err, repositories.ErrCapacityExceeded) {
+		// Another order was assigned to the partner since the check above
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Not enough capacity left for this order",
+		}, nil
+	}
+	if err != nil {
 		return &entities.ResponseMessage{
 			Success: false,
 			Error:   "Failed to accept order",
 		}, err
 	}
 
+	if offer != nil {
+		if _, err := uc.offerRepo.Respond(offer.OfferID, "accepted", ""); err != nil {
+			log.Printf("❌ Failed to record accepted offer %s: %v", offer.OfferID, err)
+		}
+		if err := uc.offerRepo.WithdrawPending(deliveryID); err != nil {
+			log.Printf("❌ Failed to withdraw offers for delivery %s: %v", deliveryID, err)
+		}
+	}
+
 	return &entities.ResponseMessage{
 		Success: true,
-		Message: "Order accepted successfully",
+		Message: "Order accepted successfully. Scan the order at the warehouse to pick it up",
+	}, nil
+}
+
+// AssignOrder hands a pending order straight to a partner without waiting for
+// them to accept it. Used by the dispatcher's assign strategy, which has
+// already checked the partner's batch; the repository enforces the capacity.
+func (uc *DeliveryUseCase) AssignOrder(delivery *entities.Delivery, partner *entities.DeliveryPartner) error {
+	if delivery.Status != "pending" {
+		return errors.New("order is no longer pending")
+	}
+
+	_, _, size := deliveryLoad(delivery)
+	if !vehicleTakes(partner.VehicleType, size) {
+		return errors.New("order is too large for the partner's vehicle")
+	}
+
+	return uc.assignOrder(delivery, partner.PartnerID, capacityCheck(partner.VehicleType, delivery), &entities.TimelineEntry{Actor: "dispatcher"})
+}
+
+// CheckCapacity explains why the order does not fit the partner's vehicle
+// together with the orders they already hold, or returns "" if it does
+func (uc *DeliveryUseCase) CheckCapacity(delivery *entities.Delivery, partner *entities.DeliveryPartner) (string, error) {
+	active, err := uc.deliveryRepo.GetActiveOrdersByPartner(partner.PartnerID)
+	if err != nil {
+		return "", err
+	}
+
+	return capacityShortfall(partner.VehicleType, delivery, heldOrders(active)), nil
+}
+
+// assignOrder moves a pending order to assigned, records who assigned it in
+// the timeline and emits delivery.assigned. A nil capacity skips the check.
+func (uc *DeliveryUseCase) assignOrder(delivery *entities.Delivery, partnerID string, capacity *entities.CapacityCheck, entry *entities.TimelineEntry) error {
+	// The order service forwards the tracking link to the customer. The token
+	// is saved with the assignment so a failed accept leaves no live link.
+	eventData := map[string]interface{}{}
+	link, err := uc.trackingUseCase.PrepareTrackingLink(delivery)
+	if err != nil {
+		log.Printf("⚠️  Failed to prepare tracking link for delivery %s: %v", delivery.DeliveryID, err)
+		link = nil
+	} else {
+		eventData["trackingUrl"] = link.URL
+	}
+
+	entry.Type = "assigned"
+	entry.Status = "assigned"
+	entry.PartnerID = partnerID
+
+	delivery.PartnerID = partnerID
+	event := newDeliveryEvent(delivery, "assigned", eventData)
+	if err := uc.deliveryRepo.AcceptOrder(delivery.DeliveryID, partnerID, capacity, link, entry, event); err != nil {
+		return err
+	}
+
+	if link != nil {
+		delivery.TrackingToken = link.Token
+		delivery.TrackingExpiresAt = &link.ExpiresAt
+	}
+	return nil
+}
+
+// reassignOrder moves an order not picked up yet to another partner, with
+// one timeline entry and one delivery.assigned event naming the previous partner
+func (uc *DeliveryUseCase) reassignOrder(delivery *entities.Delivery, partnerID string, capacity *entities.CapacityCheck, entry *entities.TimelineEntry) error {
+	link, err := uc.trackingUseCase.PrepareTrackingLink(delivery)
+	if err != nil {
+		log.Printf("⚠️  Failed to prepare tracking link for delivery %s: %v", delivery.DeliveryID, err)
+		link = nil
+	}
+
+	previousPartnerID := delivery.PartnerID
+	eventData := map[string]interface{}{
+		"previousPartnerId": previousPartnerID,
+		"reason":            entry.Reason,
+		"actor":             entry.Actor,
+	}
+	if link != nil {
+		eventData["trackingUrl"] = link.URL
+	}
+
+	entry.Type = "assigned"
+	entry.Status = "assigned"
+	entry.PartnerID = partnerID
+	entry.PreviousPartnerID = previousPartnerID
+
+	delivery.PartnerID = partnerID
+	event := newDeliveryEvent(delivery, "assigned", eventData)
+	if err := uc.deliveryRepo.ReassignOrder(delivery.DeliveryID, previousPartnerID, partnerID, capacity, link, entry, event); err != nil {
+		delivery.PartnerID = previousPartnerID
+		return err
+	}
+
+	delivery.Status = "assigned"
+	if link != nil {
+		delivery.TrackingToken = link.Token
+		delivery.TrackingExpiresAt = &link.ExpiresAt
+	}
+	return nil
+}
+
+func (uc *DeliveryUseCase) SubmitPickupScan(deliveryID, partnerID string, req *entities.PickupScanRequest) (*entities.PickupScanResponse, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.PickupScanResponse{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if delivery.PartnerID != partnerID {
+		return &entities.PickupScanResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, errors.New("unauthorized")
+	}
+
+	if delivery.Status != "assigned" {
+		return &entities.PickupScanResponse{
+			Success: false,
+			Message: "Order is not awaiting pickup",
+		}, nil
+	}
+
+	verification := delivery.PickupVerification
+	if verification == nil {
+		verification = &entities.PickupVerification{}
+	}
+
+	now := time.Now()
+	verification.ScanAttempts++
+	verification.LastScannedAt = now
+	verification.ScannedOrderCode = strings.TrimSpace(req.OrderCode)
+	verification.ScannedItemCodes = req.ItemCodes
+
+	mismatches := matchPickupScan(delivery, req)
+	for i := range mismatches {
+		mismatches[i].Attempt = verification.ScanAttempts
+		mismatches[i].ScannedAt = now
+	}
+	verification.Mismatches = append(verification.Mismatches, mismatches...)
+	verification.IsMatched = len(mismatches) == 0
+
+	if err := uc.deliveryRepo.RecordPickupScan(deliveryID, verification); err != nil {
+		return &entities.PickupScanResponse{
+			Success: false,
+			Error:   "Failed to record pickup scan",
+		}, err
+	}
+
+	message := "Scan verified. Waiting for warehouse confirmation"
+	if !verification.IsMatched {
+		message = "Scan does not match the order. Please check with the warehouse"
+	}
+
+	return &entities.PickupScanResponse{
+		Success:    true,
+		Message:    message,
+		IsMatched:  verification.IsMatched,
+		Mismatches: mismatches,
+	}, nil
+}
+
+// matchPickupScan compares the scanned codes against the order. Item codes are
+// optional; when none are scanned only the order code is checked.
+func matchPickupScan(delivery *entities.Delivery, req *entities.PickupScanRequest) []entities.PickupMismatch {
+	mismatches := make([]entities.PickupMismatch, 0)
+
+	orderCode := strings.TrimSpace(req.OrderCode)
+	if !strings.EqualFold(orderCode, delivery.OrderID) {
+		mismatches = append(mismatches, entities.PickupMismatch{
+			Type: "order_code",
+			Code: orderCode,
+		})
+	}
+
+	if len(req.ItemCodes) == 0 {
+		return mismatches
+	}
+
+	expected := make(map[string]int)
+	for _, item := range delivery.Items {
+		expected[item.ProductID] += item.Quantity
+	}
+
+	scanned := make(map[string]int)
+	for _, code := range req.ItemCodes {
+		scanned[strings.TrimSpace(code)]++
+	}
+
+	for code, count := range scanned {
+		if _, ok := expected[code]; !ok {
+			mismatches = append(mismatches, entities.PickupMismatch{
+				Type:    "unknown_item",
+				Code:    code,
+				Scanned: count,
+			})
+		}
+	}
+
+	for _, item := range delivery.Items {
+		want := expected[item.ProductID]
+		if want == 0 {
+			continue
+		}
+		got := scanned[item.ProductID]
+		switch {
+		case got == 0:
+			mismatches = append(mismatches, entities.PickupMismatch{
+				Type:     "missing_item",
+				Code:     item.ProductID,
+				Expected: want,
+			})
+		case got != want:
+			mismatches = append(mismatches, entities.PickupMismatch{
+				Type:     "quantity",
+				Code:     item.ProductID,
+				Expected: want,
+				Scanned:  got,
+			})
+		}
+		// Items may repeat a product ID; report each product once
+		expected[item.ProductID] = 0
+	}
+
+	return mismatches
+}
+
+func (uc *DeliveryUseCase) ConfirmPickup(deliveryID, actor string, req *entities.ConfirmPickupRequest) (*entities.ResponseMessage, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if delivery.Status != "assigned" {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Order is not awaiting pickup",
+		}, nil
+	}
+
+	if delivery.PickupVerification == nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Partner has not scanned the order yet",
+		}, nil
+	}
+
+	if !delivery.PickupVerification.IsMatched && !req.Override {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Last scan had mismatches. Rescan or confirm with override",
+		}, nil
+	}
+
+	if req.Override && strings.TrimSpace(req.Note) == "" {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "A note is required to override a mismatched scan",
+		}, nil
+	}
+
+	confirmedBy := req.ConfirmedBy
+	if confirmedBy == "" {
+		confirmedBy = actor
+	}
+
+	event := newDeliveryEvent(delivery, "picked_up", map[string]interface{}{
+		"confirmedBy": confirmedBy,
+	})
+	if err := uc.deliveryRepo.ConfirmPickup(deliveryID, confirmedBy, req.Note, req.Override, event); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to confirm pickup",
+		}, err
+	}
+
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: "Pickup confirmed",
 	}, nil
 }
 
@@ -152,10 +841,10 @@ func (uc *DeliveryUseCase) UpdateOrderStatus(deliveryID, partnerID string, req *
 	}
 
 	// Validate status transition
+	// picked_up is only reached through the warehouse pickup confirmation and
+	// delivered through CompleteDelivery, which also records earnings
 	validTransitions := map[string][]string{
-		"pending":    {"picked_up"},
-		"picked_up":  {"in_transit"},
-		"in_transit": {"delivered"},
+		"picked_up": {"in_transit"},
 	}
 
 	validNext, ok := validTransitions[delivery.Status]
@@ -181,16 +870,16 @@ func (uc *DeliveryUseCase) UpdateOrderStatus(deliveryID, partnerID string, req *
 		}, nil
 	}
 
-	if err := uc.deliveryRepo.UpdateStatus(deliveryID, req.Status); err != nil {
+	event := newDeliveryEvent(delivery, req.Status, nil)
+	if err := uc.deliveryRepo.UpdateStatus(deliveryID, partnerID, delivery.Status, req.Status, event); err != nil {
 		return &entities.ResponseMessage{
 			Success: false,
 			Error:   "Failed to update status",
 		}, err
 	}
 
-	// Update partner location
 	if req.Latitude != 0 && req.Longitude != 0 {
-		uc.partnerRepo.UpdateLocation(partnerID, req.Latitude, req.Longitude)
+		uc.recordLocation(partnerID, req.Latitude, req.Longitude)
 	}
 
 	return &entities.ResponseMessage{
@@ -222,38 +911,46 @@ func (uc *DeliveryUseCase) CompleteDelivery(deliveryID, partnerID string, req *e
 		}, nil
 	}
 
-	if err := uc.deliveryRepo.CompleteDelivery(deliveryID, req.Notes); err != nil {
+	if delivery.WindowStart != nil && time.Now().Before(*delivery.WindowStart) {
 		return &entities.ResponseMessage{
 			Success: false,
-			Error:   "Failed to complete delivery",
-		}, err
+			Message: "You are early. The customer's delivery window opens at " + delivery.WindowStart.Format(time.RFC3339) + ", please wait",
+		}, nil
 	}
 
-	// Create earnings record
-	bonus := 0
-	if delivery.DeliveryFee > 100 {
-		bonus = 10 // Bonus for high-value deliveries
-	}
+	// The drop fix is checked like any other, before the flags below are read
+	uc.recordLocation(partnerID, req.Latitude, req.Longitude)
 
-	earnings := &entities.Earnings{
-		PartnerID:    partnerID,
-		DeliveryID:   deliveryID,
-		OrderID:      delivery.OrderID,
-		Amount:       delivery.OrderAmount,
-		DeliveryFee:  delivery.DeliveryFee,
-		Bonus:        bonus,
-		TotalEarning: delivery.DeliveryFee + bonus,
-		EarnedAt:     time.Now(),
+	if uc.blockFlaggedCompletion {
+		delivery, err = uc.deliveryRepo.GetByID(deliveryID)
+		if err != nil {
+			return &entities.ResponseMessage{
+				Success: false,
+				Error:   "Order not found",
+			}, err
+		}
 	}
 
-	if err := uc.earningsRepo.Create(earnings); err != nil {
-		// Log error but don't fail the completion
-		// In production, use proper logging
+	if uc.blockFlaggedCompletion && delivery.OpenLocationFlags > 0 {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Location checks flagged this delivery. Please contact support to complete it",
+		}, nil
 	}
 
-	// Update partner location
-	if req.Latitude != 0 && req.Longitude != 0 {
-		uc.partnerRepo.UpdateLocation(partnerID, req.Latitude, req.Longitude)
+	deliveredAt := time.Now()
+	earnings := newDeliveryEarnings(delivery, deliveredAt)
+	event := newDeliveryEvent(delivery, "delivered", nil)
+
+	// Whether the SLA was met is written with the completion, so the SLA
+	// checker can't overwrite it
+	slaStatus := finalSLAStatus(delivery, deliveredAt, uc.sla)
+
+	if err := uc.deliveryRepo.CompleteDelivery(deliveryID, req.Notes, slaStatus, earnings, event); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to complete delivery",
+		}, err
 	}
 
 	return &entities.ResponseMessage{
@@ -262,3 +959,63 @@ func (uc *DeliveryUseCase) CompleteDelivery(deliveryID, partnerID string, req *e
 	}, nil
 }
 
+// recordLocation saves coordinates sent along with a status change as a
+// location update, so they get the integrity checks, history and live
+// tracking of any other fix
+func (uc *DeliveryUseCase) recordLocation(partnerID string, latitude, longitude float64) {
+	req := &entities.UpdateLocationRequest{Latitude: latitude, Longitude: longitude}
+	if _, err := uc.locationUseCase.UpdateLocation(partnerID, req); err != nil {
+		log.Printf("❌ Failed to update location of partner %s: %v", partnerID, err)
+	}
+}
+
+
+// dueFrom is when a delivery should be worked on: its window start, or its
+// creation time for deliver-now orders
+func dueFrom(delivery *entities.Delivery) time.Time {
+	if delivery.WindowStart != nil {
+		return *delivery.WindowStart
+	}
+	return delivery.CreatedAt
+}
+
+// releaseTime is when a scheduled order becomes available for pickup
+func (uc *DeliveryUseCase) releaseTime(delivery *entities.Delivery) *time.Time {
+	if delivery.WindowStart == nil {
+		return nil
+	}
+	releaseAt := delivery.WindowStart.Add(-uc.scheduleLeadTime)
+	return &releaseAt
+}
+
+func (uc *DeliveryUseCase) GetSlotAdherence(req *entities.GetSlotAdherenceRequest) (*entities.GetSlotAdherenceResponse, error) {
+	if req.Period == "" {
+		req.Period = "week"
+	}
+	if req.GroupBy != "warehouse" {
+		req.GroupBy = "partner"
+	}
+
+	groups, err := uc.deliveryRepo.GetSlotAdherence(req.GroupBy, req.Period, req.PartnerID, req.WarehouseID)
+	if err != nil {
+		return &entities.GetSlotAdherenceResponse{
+			Success: false,
+		}, err
+	}
+
+	if groups == nil {
+		groups = []entities.SlotAdherence{}
+	}
+	for i := range groups {
+		if groups[i].Total > 0 {
+			groups[i].AdherenceRate = float64(groups[i].OnTime) / float64(groups[i].Total)
+		}
+	}
+
+	return &entities.GetSlotAdherenceResponse{
+		Success: true,
+		Period:  req.Period,
+		GroupBy: req.GroupBy,
+		Groups:  groups,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"deliveryAppBackend/domain/entities"
+	"log"
+	"time"
+)
+
+// Dispatch strategies
+const (
+	DispatchStrategyOff    = "off"    // orders wait for partners to pick them
+	DispatchStrategyOffer  = "offer"  // best partner sees the order and must accept it
+	DispatchStrategyAssign = "assign" // best partner gets the order straight away
+)
+
+// validDispatchStrategy reports whether the strategy is one of the above
+func validDispatchStrategy(strategy string) bool {
+	switch strategy {
+	case DispatchStrategyOff, DispatchStrategyOffer, DispatchStrategyAssign:
+		return true
+	}
+	return false
+}
+
+// OrderAssigner gives an order to a partner, enforcing what their vehicle can
+// carry. DeliveryUseCase is the real one.
+type OrderAssigner interface {
+	AssignOrder(delivery *entities.Delivery, partner *entities.DeliveryPartner) error
+}
+
+// Dispatcher matches pending deliveries to available partners. Implementations
+// depend only on repository interfaces and an OrderAssigner so they can run
+// against in-memory repos.
+type Dispatcher interface {
+	Name() string
+	// Dispatch makes one pass over the pending deliveries
+	Dispatch() (*entities.DispatchRunResponse, error)
+	// Redispatch finds the next candidate for a single delivery, leaving out
+	// the given partners, e.g. the one the order was just taken from
+	Redispatch(deliveryID string, excludePartnerIDs []string) (*entities.DispatchDecision, error)
+	// RankCandidates scores the eligible partners for one delivery, best first
+	RankCandidates(deliveryID string) (*entities.GetDispatchCandidatesResponse, error)
+}
+
+// RunDispatcher calls Dispatch every interval until the context is cancelled
+func RunDispatcher(ctx context.Context, dispatcher Dispatcher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := dispatcher.Dispatch()
+			if err != nil {
+				log.Printf("❌ Dispatch (%s) failed: %v", dispatcher.Name(), err)
+				continue
+			}
+			if result.Dispatched > 0 {
+				log.Printf("🚚 Dispatched %d of %d pending orders (%s)", result.Dispatched, result.Considered, result.Strategy)
+			}
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package usecase
 
 import (
+	"context"
 	"deliveryAppBackend/domain/entities"
 	"deliveryAppBackend/domain/repositories"
+	"log"
+	"time"
 )
 
 type EarningsUseCase struct {
@@ -94,3 +97,66 @@ func (uc *EarningsUseCase) GetEarningsHistory(partnerID string, req *entities.Ge
 	}, nil
 }
 
+
+// newDeliveryEarnings builds the earnings record paid out for a completed delivery
+func newDeliveryEarnings(delivery *entities.Delivery, earnedAt time.Time) *entities.Earnings {
+	bonus := 0
+	if delivery.DeliveryFee > 100 {
+		bonus = 10 // Bonus for high-value deliveries
+	}
+
+	return &entities.Earnings{
+		PartnerID:    delivery.PartnerID,
+		DeliveryID:   delivery.DeliveryID,
+		OrderID:      delivery.OrderID,
+		Amount:       delivery.OrderAmount,
+		DeliveryFee:  delivery.DeliveryFee,
+		Bonus:        bonus,
+		TotalEarning: delivery.DeliveryFee + bonus,
+		EarnedAt:     earnedAt,
+	}
+}
+
+// ReconcileMissingEarnings creates earnings for delivered orders that have none,
+// e.g. orders completed before completion became transactional.
+func (uc *EarningsUseCase) ReconcileMissingEarnings(limit int) (int, error) {
+	deliveries, err := uc.deliveryRepo.GetDeliveredWithoutEarnings(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range deliveries {
+		d := &deliveries[i]
+		earnedAt := d.UpdatedAt
+		if d.DeliveredAt != nil {
+			earnedAt = *d.DeliveredAt
+		}
+
+		if err := uc.earningsRepo.Create(newDeliveryEarnings(d, earnedAt)); err != nil {
+			log.Printf("❌ Failed to reconcile earnings for delivery %s: %v", d.DeliveryID, err)
+			continue
+		}
+		log.Printf("⚠️  Reconciled missing earnings for delivery %s (partner %s)", d.DeliveryID, d.PartnerID)
+		created++
+	}
+
+	return created, nil
+}
+
+// RunReconciliation periodically repairs delivered orders without earnings
+func (uc *EarningsUseCase) RunReconciliation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := uc.ReconcileMissingEarnings(100); err != nil {
+				log.Printf("❌ Earnings reconciliation failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/services"
+	"deliveryAppBackend/utils"
+	"math"
+	"time"
+)
+
+// Average door-to-door speeds used for ETAs, by vehicle type
+var vehicleAverageSpeedKmh = map[string]float64{
+	"bike":    25,
+	"scooter": 22,
+	"car":     18,
+}
+
+const (
+	defaultAverageSpeedKmh = 20
+	// Time spent at the warehouse for the scan and handover
+	pickupHandoverTime = 5 * time.Minute
+)
+
+func averageSpeedKmh(vehicleType string) float64 {
+	if speed, ok := vehicleAverageSpeedKmh[vehicleType]; ok {
+		return speed
+	}
+	return defaultAverageSpeedKmh
+}
+
+// estimateArrival projects when the delivery reaches the customer. Before pickup
+// the route runs via the warehouse; without a partner location the pickup is
+// used as the starting point. Travel time comes from the vehicle speed model
+// over the road distance reported by the route provider.
+func estimateArrival(delivery *entities.Delivery, partner *entities.DeliveryPartner, routes services.RouteProvider, now time.Time) (time.Time, float64) {
+	remainingKm := 0.0
+	extra := time.Duration(0)
+
+	hasLocation := partner != nil && (partner.CurrentLatitude != 0 || partner.CurrentLongitude != 0)
+
+	switch delivery.Status {
+	case "picked_up", "in_transit":
+		if hasLocation {
+			remainingKm = routeKm(routes, partnerCoordinate(partner), dropCoordinate(delivery))
+		} else {
+			remainingKm = pickupToDropKm(delivery)
+		}
+	default:
+		if hasLocation {
+			remainingKm = routeKm(routes, partnerCoordinate(partner), pickupCoordinate(delivery))
+		}
+		remainingKm += pickupToDropKm(delivery)
+		extra = pickupHandoverTime
+	}
+
+	vehicleType := ""
+	if partner != nil {
+		vehicleType = partner.VehicleType
+	}
+
+	travel := time.Duration(remainingKm / averageSpeedKmh(vehicleType) * float64(time.Hour))
+	return now.Add(travel + extra), remainingKm
+}
+
+func pickupToDropKm(delivery *entities.Delivery) float64 {
+	if delivery.Distance > 0 {
+		return delivery.Distance
+	}
+	return utils.HaversineKm(delivery.PickupLatitude, delivery.PickupLongitude, delivery.DeliveryLatitude, delivery.DeliveryLongitude)
+}
+
+// routeKm is the road distance between two points, or the straight line if
+// the provider has no answer
+func routeKm(routes services.RouteProvider, from, to entities.Coordinate) float64 {
+	route, err := routes.Route(from, to)
+	if err != nil {
+		return utils.HaversineKm(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	}
+	return route.DistanceKm
+}
+
+// deliveryRoute computes the pickup to drop route, with the distance rounded
+// to 10 m
+func deliveryRoute(routes services.RouteProvider, delivery *entities.Delivery) (*entities.Route, error) {
+	route, err := routes.Route(pickupCoordinate(delivery), dropCoordinate(delivery))
+	if err != nil {
+		return nil, err
+	}
+	route.DistanceKm = math.Round(route.DistanceKm*100) / 100
+	return route, nil
+}
+
+func applyRoute(delivery *entities.Delivery, route *entities.Route) {
+	delivery.Distance = route.DistanceKm
+	delivery.Duration = route.DurationSeconds
+	delivery.RouteSource = route.Source
+}
+
+func pickupCoordinate(delivery *entities.Delivery) entities.Coordinate {
+	return entities.Coordinate{Latitude: delivery.PickupLatitude, Longitude: delivery.PickupLongitude}
+}
+
+func dropCoordinate(delivery *entities.Delivery) entities.Coordinate {
+	return entities.Coordinate{Latitude: delivery.DeliveryLatitude, Longitude: delivery.DeliveryLongitude}
+}
+
+func partnerCoordinate(partner *entities.DeliveryPartner) entities.Coordinate {
+	return entities.Coordinate{Latitude: partner.CurrentLatitude, Longitude: partner.CurrentLongitude}
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/utils"
+	"time"
+)
+
+// EventPublisher receives delivery lifecycle events
+type EventPublisher interface {
+	Publish(event *entities.DeliveryEvent) error
+}
+
+func newDeliveryEvent(delivery *entities.Delivery, status string, data map[string]interface{}) *entities.DeliveryEvent {
+	eventID, err := utils.RandomHex(12)
+	if err != nil {
+		eventID = time.Now().Format("20060102150405.000000000")
+	}
+
+	return &entities.DeliveryEvent{
+		EventID:    "evt_" + eventID,
+		Type:       "delivery." + status,
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+		PartnerID:  delivery.PartnerID,
+		Status:     status,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+}
+
+// EventPublishers publishes to each publisher in turn and stops at the first
+// error. The outbox relay retries the whole list, so publishers that come
+// before a failing one may see an event more than once.
+type EventPublishers []EventPublisher
+
+func (p EventPublishers) Publish(event *entities.DeliveryEvent) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
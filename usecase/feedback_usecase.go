@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"errors"
+	"log"
+	"math"
+	"strings"
+	"time"
+)
+
+type FeedbackUseCase struct {
+	feedbackRepo    repositories.FeedbackRepository
+	deliveryRepo    repositories.DeliveryRepository
+	partnerRepo     repositories.DeliveryPartnerRepository
+	trackingUseCase *TrackingUseCase
+	// A rating loses half its weight in the partner's average every halfLife
+	halfLife time.Duration
+}
+
+func NewFeedbackUseCase(
+	feedbackRepo repositories.FeedbackRepository,
+	deliveryRepo repositories.DeliveryRepository,
+	partnerRepo repositories.DeliveryPartnerRepository,
+	trackingUseCase *TrackingUseCase,
+	halfLife time.Duration,
+) *FeedbackUseCase {
+	return &FeedbackUseCase{
+		feedbackRepo:    feedbackRepo,
+		deliveryRepo:    deliveryRepo,
+		partnerRepo:     partnerRepo,
+		trackingUseCase: trackingUseCase,
+		halfLife:        halfLife,
+	}
+}
+
+// SubmitCustomerFeedback records feedback left through the tracking link
+func (uc *FeedbackUseCase) SubmitCustomerFeedback(token string, req *entities.SubmitFeedbackRequest) (*entities.SubmitFeedbackResponse, error) {
+	delivery, err := uc.trackingUseCase.ResolveToken(token)
+	if err != nil {
+		return &entities.SubmitFeedbackResponse{
+			Success: false,
+			Error:   "Failed to load order",
+		}, err
+	}
+
+	if delivery == nil {
+		return &entities.SubmitFeedbackResponse{
+			Success: false,
+			Error:   "Tracking link is invalid or has expired",
+		}, nil
+	}
+
+	return uc.submitFeedback(delivery, "customer", req)
+}
+
+// SubmitOrderFeedback records feedback the order service collected itself
+func (uc *FeedbackUseCase) SubmitOrderFeedback(deliveryID string, req *entities.SubmitFeedbackRequest) (*entities.SubmitFeedbackResponse, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.SubmitFeedbackResponse{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	return uc.submitFeedback(delivery, "order_service", req)
+}
+
+func (uc *FeedbackUseCase) submitFeedback(delivery *entities.Delivery, source string, req *entities.SubmitFeedbackRequest) (*entities.SubmitFeedbackResponse, error) {
+	if delivery.Status != "delivered" || delivery.PartnerID == "" {
+		return &entities.SubmitFeedbackResponse{
+			Success: false,
+			Message: "Feedback can only be left for delivered orders",
+		}, nil
+	}
+
+	existing, err := uc.feedbackRepo.GetByDeliveryID(delivery.DeliveryID)
+	if err != nil {
+		return &entities.SubmitFeedbackResponse{
+			Success: false,
+			Error:   "Failed to check existing feedback",
+		}, err
+	}
+
+	if existing != nil {
+		return &entities.SubmitFeedbackResponse{
+			Success: false,
+			Message: "Feedback was already submitted for this order",
+		}, nil
+	}
+
+	feedback := &entities.Feedback{
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+		PartnerID:  delivery.PartnerID,
+		Rating:     req.Rating,
+		Tags:       normalizeTags(req.Tags),
+		Comment:    strings.TrimSpace(req.Comment),
+		Source:     source,
+	}
+
+	// The check above can race a concurrent submit; the unique index can't
+	err = uc.feedbackRepo.Create(feedback)
+	if errors.Is(err, repositories.ErrFeedbackExists) {
+		return &entities.SubmitFeedbackResponse{
+			Success: false,
+			Message: "Feedback was already submitted for this order",
+		}, nil
+	}
+	if err != nil {
+		return &entities.SubmitFeedbackResponse{
+			Success: false,
+			Error:   "Failed to save feedback",
+		}, err
+	}
+
+	// The feedback is stored; a failed recompute is picked up by the next change
+	if _, err := uc.recomputeRating(delivery.PartnerID); err != nil {
+		log.Printf("❌ Failed to update rating for partner %s: %v", delivery.PartnerID, err)
+	}
+
+	return &entities.SubmitFeedbackResponse{
+		Success:  true,
+		Message:  "Thanks for your feedback",
+		Feedback: feedback,
+	}, nil
+}
+
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// ExcludeFeedback removes an outlier from the partner's rating. The feedback
+// itself is kept.
+func (uc *FeedbackUseCase) ExcludeFeedback(feedbackID, actor string, req *entities.ExcludeFeedbackRequest) (*entities.ResponseMessage, error) {
+	return uc.setExcluded(feedbackID, true, actor, req.Reason)
+}
+
+func (uc *FeedbackUseCase) IncludeFeedback(feedbackID, actor string) (*entities.ResponseMessage, error) {
+	return uc.setExcluded(feedbackID, false, actor, "")
+}
+
+func (uc *FeedbackUseCase) setExcluded(feedbackID string, excluded bool, actor, reason string) (*entities.ResponseMessage, error) {
+	feedback, err := uc.feedbackRepo.GetByID(feedbackID)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Feedback not found",
+		}, err
+	}
+
+	if err := uc.feedbackRepo.SetExcluded(feedbackID, excluded, actor, reason); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to update feedback",
+		}, err
+	}
+
+	if _, err := uc.recomputeRating(feedback.PartnerID); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to update partner rating",
+		}, err
+	}
+
+	message := "Feedback included in rating"
+	if excluded {
+		message = "Feedback excluded from rating"
+	}
+
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+func (uc *FeedbackUseCase) GetPartnerFeedback(partnerID string, req *entities.GetPartnerFeedbackRequest) (*entities.GetPartnerFeedbackResponse, error) {
+	partner, err := uc.partnerRepo.FindByID(partnerID)
+	if err != nil {
+		return &entities.GetPartnerFeedbackResponse{
+			Success: false,
+		}, err
+	}
+
+	feedback, total, err := uc.feedbackRepo.GetByPartner(partnerID, req.Limit, req.Offset)
+	if err != nil {
+		return &entities.GetPartnerFeedbackResponse{
+			Success: false,
+		}, err
+	}
+
+	if feedback == nil {
+		feedback = []entities.Feedback{}
+	}
+
+	return &entities.GetPartnerFeedbackResponse{
+		Success:     true,
+		Rating:      partner.Rating,
+		Feedback:    feedback,
+		Total:       total,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+		HasNext:     (req.Offset + req.Limit) < total,
+		HasPrevious: req.Offset > 0,
+	}, nil
+}
+
+// recomputeRating stores the time-decayed average of the partner's rated
+// feedback and returns it
+func (uc *FeedbackUseCase) recomputeRating(partnerID string) (float64, error) {
+	feedback, err := uc.feedbackRepo.GetRatedFeedback(partnerID)
+	if err != nil {
+		return 0, err
+	}
+
+	rating := decayedAverage(feedback, time.Now(), uc.halfLife)
+	return rating, uc.partnerRepo.UpdateRating(partnerID, rating)
+}
+
+// decayedAverage weights every rating by 0.5^(age/halfLife) so recent
+// deliveries count the most. Returns 0 when there is nothing to average.
+func decayedAverage(feedback []entities.Feedback, now time.Time, halfLife time.Duration) float64 {
+	var weighted, totalWeight float64
+	for _, f := range feedback {
+		weight := 1.0
+		if halfLife > 0 {
+			age := now.Sub(f.CreatedAt)
+			if age < 0 {
+				age = 0
+			}
+			weight = math.Pow(0.5, age.Hours()/halfLife.Hours())
+		}
+		weighted += weight * float64(f.Rating)
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+
+	return math.Round(weighted/totalWeight*100) / 100
+}
@@ -0,0 +1,399 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"errors"
+	"log"
+	"time"
+)
+
+// FleetUseCase backs the ops fleet console: who is out, which orders need a
+// partner, and manual assignment with an audit trail
+type FleetUseCase struct {
+	deliveryRepo    repositories.DeliveryRepository
+	partnerRepo     repositories.DeliveryPartnerRepository
+	zoneRepo        repositories.ZoneRepository
+	offerRepo       repositories.OfferRepository
+	deliveryUseCase *DeliveryUseCase
+}
+
+func NewFleetUseCase(
+	deliveryRepo repositories.DeliveryRepository,
+	partnerRepo repositories.DeliveryPartnerRepository,
+	zoneRepo repositories.ZoneRepository,
+	offerRepo repositories.OfferRepository,
+	deliveryUseCase *DeliveryUseCase,
+) *FleetUseCase {
+	return &FleetUseCase{
+		deliveryRepo:    deliveryRepo,
+		partnerRepo:     partnerRepo,
+		zoneRepo:        zoneRepo,
+		offerRepo:       offerRepo,
+		deliveryUseCase: deliveryUseCase,
+	}
+}
+
+func (uc *FleetUseCase) GetPartners(req *entities.GetFleetPartnersRequest) (*entities.GetFleetPartnersResponse, error) {
+	filter := &repositories.PartnerFilter{}
+	if req.Available != "" {
+		available := req.Available == "true"
+		filter.IsAvailable = &available
+	}
+
+	// Partners aren't tied to a warehouse; they belong to its zones
+	zones, err := uc.filterZones(req.ZoneID, req.WarehouseID)
+	if errors.Is(err, repositories.ErrZoneNotFound) {
+		return &entities.GetFleetPartnersResponse{
+			Success: false,
+			Message: "Zone not found",
+		}, nil
+	}
+	if err != nil {
+		return &entities.GetFleetPartnersResponse{
+			Success: false,
+			Error:   "Failed to load zones",
+		}, err
+	}
+	filter.Zones = zones
+
+	partners, total, err := uc.partnerRepo.FindPartners(filter, req.Limit, req.Offset)
+	if err != nil {
+		return &entities.GetFleetPartnersResponse{
+			Success: false,
+			Error:   "Failed to load partners",
+		}, err
+	}
+
+	ids := make([]string, 0, len(partners))
+	for _, p := range partners {
+		ids = append(ids, p.PartnerID)
+	}
+	counts := map[string]int{}
+	if len(ids) > 0 {
+		counts, err = uc.deliveryRepo.CountActiveOrdersByPartners(ids)
+		if err != nil {
+			return &entities.GetFleetPartnersResponse{
+				Success: false,
+				Error:   "Failed to count active orders",
+			}, err
+		}
+	}
+
+	items := make([]entities.FleetPartner, 0, len(partners))
+	for _, p := range partners {
+		items = append(items, entities.FleetPartner{
+			ID:              p.PartnerID,
+			Name:            p.Name,
+			PhoneNumber:     p.PhoneNumber,
+			VehicleType:     p.VehicleType,
+			IsAvailable:     p.IsAvailable,
+			Presence:        partnerPresence(&p, counts[p.PartnerID]),
+			HomeZoneID:      p.HomeZoneID,
+			Latitude:        p.CurrentLatitude,
+			Longitude:       p.CurrentLongitude,
+			LastLocationAt:  optionalTime(p.LastLocationAt),
+			LastHeartbeatAt: optionalTime(p.LastHeartbeatAt),
+			ActiveOrders:    counts[p.PartnerID],
+			Rating:          p.Rating,
+		})
+	}
+
+	return &entities.GetFleetPartnersResponse{
+		Success:     true,
+		Partners:    items,
+		Total:       total,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+		HasNext:     (req.Offset + req.Limit) < total,
+		HasPrevious: req.Offset > 0,
+	}, nil
+}
+
+// GetUnassignedOrders lists pending orders nobody holds, oldest first
+func (uc *FleetUseCase) GetUnassignedOrders(req *entities.GetFleetOrdersRequest) (*entities.GetFleetOrdersResponse, error) {
+	return uc.getOrders(req, &repositories.DeliveryFilter{
+		Statuses:   []string{"pending"},
+		Unassigned: true,
+	})
+}
+
+// GetAtRiskOrders lists open orders flagged at_risk or breached by the SLA checker
+func (uc *FleetUseCase) GetAtRiskOrders(req *entities.GetFleetOrdersRequest) (*entities.GetFleetOrdersResponse, error) {
+	return uc.getOrders(req, &repositories.DeliveryFilter{
+		Statuses:    []string{"pending", "assigned", "picked_up", "in_transit"},
+		SLAStatuses: []string{"at_risk", "breached"},
+	})
+}
+
+func (uc *FleetUseCase) getOrders(req *entities.GetFleetOrdersRequest, filter *repositories.DeliveryFilter) (*entities.GetFleetOrdersResponse, error) {
+	filter.WarehouseID = req.WarehouseID
+	if req.ZoneID != "" {
+		zones, err := uc.filterZones(req.ZoneID, "")
+		if errors.Is(err, repositories.ErrZoneNotFound) {
+			return &entities.GetFleetOrdersResponse{
+				Success: false,
+				Message: "Zone not found",
+			}, nil
+		}
+		if err != nil {
+			return &entities.GetFleetOrdersResponse{
+				Success: false,
+				Error:   "Failed to load zones",
+			}, err
+		}
+		filter.Zones = zones
+	}
+
+	deliveries, total, err := uc.deliveryRepo.FindDeliveries(filter, req.Limit, req.Offset)
+	if err != nil {
+		return &entities.GetFleetOrdersResponse{
+			Success: false,
+			Error:   "Failed to load orders",
+		}, err
+	}
+
+	now := time.Now()
+	orders := make([]entities.FleetOrder, 0, len(deliveries))
+	for _, d := range deliveries {
+		_, _, size := deliveryLoad(&d)
+		orders = append(orders, entities.FleetOrder{
+			ID:              d.DeliveryID,
+			OrderID:         d.OrderID,
+			Status:          d.Status,
+			PartnerID:       d.PartnerID,
+			WarehouseID:     d.WarehouseID,
+			PickupAddress:   d.PickupAddress,
+			DeliveryAddress: d.DeliveryAddress,
+			SizeClass:       size,
+			SLAStatus:       d.SLAStatus,
+			SLADeadline:     d.SLADeadline,
+			ETA:             d.ETA,
+			MinutesLate:     minutesLate(&d, now),
+			WindowStart:     d.WindowStart,
+			WindowEnd:       d.WindowEnd,
+			CreatedAt:       d.CreatedAt,
+		})
+	}
+
+	return &entities.GetFleetOrdersResponse{
+		Success:     true,
+		Orders:      orders,
+		Total:       total,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+		HasNext:     (req.Offset + req.Limit) < total,
+		HasPrevious: req.Offset > 0,
+	}, nil
+}
+
+// AssignOrder hands an unassigned pending order to a partner chosen by ops
+func (uc *FleetUseCase) AssignOrder(deliveryID, actor string, req *entities.ManualAssignRequest) (*entities.ResponseMessage, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if delivery.Status != "pending" || delivery.PartnerID != "" {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Order is already assigned. Use reassign instead",
+		}, nil
+	}
+
+	return uc.assign(delivery, actor, "", req)
+}
+
+// UnassignOrder takes an order that hasn't been picked up back from its
+// partner and returns it to the pool
+func (uc *FleetUseCase) UnassignOrder(deliveryID, actor string, req *entities.ManualUnassignRequest) (*entities.ResponseMessage, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if message := releasable(delivery); message != "" {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: message,
+		}, nil
+	}
+
+	if err := uc.release(delivery, actor, req.Reason); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to unassign order",
+		}, err
+	}
+
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: "Order returned to the pool",
+	}, nil
+}
+
+// ReassignOrder moves an order that hasn't been picked up to another partner
+func (uc *FleetUseCase) ReassignOrder(deliveryID, actor string, req *entities.ManualAssignRequest) (*entities.ResponseMessage, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if message := releasable(delivery); message != "" {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: message,
+		}, nil
+	}
+	if delivery.PartnerID == req.PartnerID {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Order is already with this partner",
+		}, nil
+	}
+
+	return uc.assign(delivery, actor, delivery.PartnerID, req)
+}
+
+func (uc *FleetUseCase) assign(delivery *entities.Delivery, actor, previousPartnerID string, req *entities.ManualAssignRequest) (*entities.ResponseMessage, error) {
+	partner, message, err := uc.checkPartner(delivery, req)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to assign order",
+		}, err
+	}
+	if message != "" {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: message,
+		}, nil
+	}
+
+	// Forced assignments may overload the vehicle on purpose
+	var capacity *entities.CapacityCheck
+	if !req.Force {
+		capacity = capacityCheck(partner.VehicleType, delivery)
+	}
+
+	// A reassignment is one write that only applies while the previous
+	// partner still holds the order, so a failure leaves it with them
+	entry := &entities.TimelineEntry{
+		Actor:  actor,
+		Reason: req.Reason,
+	}
+	if previousPartnerID == "" {
+		err = uc.deliveryUseCase.assignOrder(delivery, req.PartnerID, capacity, entry)
+	} else {
+		err = uc.deliveryUseCase.reassignOrder(delivery, req.PartnerID, capacity, entry)
+	}
+	if errors.Is(err, repositories.ErrCapacityExceeded) {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "Not enough capacity left for this order. Use force to assign anyway",
+		}, nil
+	}
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to assign order",
+		}, err
+	}
+
+	// Offers still out for the order can no longer be accepted
+	if err := uc.offerRepo.WithdrawPending(delivery.DeliveryID); err != nil {
+		log.Printf("❌ Failed to withdraw offers for delivery %s: %v", delivery.DeliveryID, err)
+	}
+
+	message = "Order assigned"
+	if previousPartnerID != "" {
+		message = "Order reassigned"
+	}
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+func (uc *FleetUseCase) release(delivery *entities.Delivery, actor, reason string) error {
+	entry := &entities.TimelineEntry{
+		Type:              "released",
+		Status:            delivery.Status,
+		PreviousPartnerID: delivery.PartnerID,
+		Actor:             actor,
+		Reason:            reason,
+	}
+	event := newDeliveryEvent(delivery, "unassigned", map[string]interface{}{
+		"reason": reason,
+		"actor":  actor,
+	})
+	return uc.deliveryRepo.ReleaseOrder(delivery.DeliveryID, delivery.PartnerID, entry, event)
+}
+
+// checkPartner loads the partner and explains why they can't take the order,
+// or returns "" if they can
+func (uc *FleetUseCase) checkPartner(delivery *entities.Delivery, req *entities.ManualAssignRequest) (*entities.DeliveryPartner, string, error) {
+	partner, err := uc.partnerRepo.FindByID(req.PartnerID)
+	if errors.Is(err, repositories.ErrPartnerNotFound) {
+		return nil, "Partner not found", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if !partner.IsVerified {
+		return partner, "Partner is not verified", nil
+	}
+	if req.Force {
+		return partner, "", nil
+	}
+	message, err := uc.deliveryUseCase.CheckCapacity(delivery, partner)
+	return partner, message, err
+}
+
+// filterZones resolves the console's zone or warehouse filter to zones. Nil
+// means no filter; a warehouse without zones gives an empty, non-nil list.
+// An unknown zone gives repositories.ErrZoneNotFound.
+func (uc *FleetUseCase) filterZones(zoneID, warehouseID string) ([]entities.Zone, error) {
+	if zoneID != "" {
+		zone, err := uc.zoneRepo.GetByID(zoneID)
+		if err != nil {
+			return nil, err
+		}
+		return []entities.Zone{*zone}, nil
+	}
+	if warehouseID != "" {
+		zones, err := uc.zoneRepo.GetByWarehouse(warehouseID)
+		if err != nil {
+			return nil, err
+		}
+		return zones, nil
+	}
+	return nil, nil
+}
+
+// releasable explains why ops can't take the order back, or returns ""
+func releasable(delivery *entities.Delivery) string {
+	if delivery.PartnerID == "" {
+		return "Order is not assigned"
+	}
+	if delivery.Status != "pending" && delivery.Status != "assigned" {
+		return "Order has been picked up and can't be taken back"
+	}
+	return ""
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
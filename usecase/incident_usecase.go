@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/domain/services"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+type IncidentUseCase struct {
+	incidentRepo repositories.IncidentRepository
+	deliveryRepo repositories.DeliveryRepository
+	notifier     services.Notifier
+}
+
+func NewIncidentUseCase(
+	incidentRepo repositories.IncidentRepository,
+	deliveryRepo repositories.DeliveryRepository,
+	notifier services.Notifier,
+) *IncidentUseCase {
+	return &IncidentUseCase{
+		incidentRepo: incidentRepo,
+		deliveryRepo: deliveryRepo,
+		notifier:     notifier,
+	}
+}
+
+func (uc *IncidentUseCase) ReportIncident(deliveryID, partnerID string, req *entities.ReportIncidentRequest) (*entities.IncidentResponse, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.IncidentResponse{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if delivery.PartnerID != partnerID {
+		return &entities.IncidentResponse{
+			Success: false,
+			Message: "Unauthorized",
+		}, errors.New("unauthorized")
+	}
+
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return &entities.IncidentResponse{
+			Success: false,
+			Message: "Latitude and longitude must be sent together",
+		}, nil
+	}
+
+	incident := &entities.Incident{
+		DeliveryID:     deliveryID,
+		OrderID:        delivery.OrderID,
+		PartnerID:      partnerID,
+		Category:       req.Category,
+		Severity:       req.Severity,
+		Description:    strings.TrimSpace(req.Description),
+		PhotoURLs:      req.PhotoURLs,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		DeliveryStatus: delivery.Status,
+		Status:         "open",
+	}
+
+	if err := uc.incidentRepo.Create(incident); err != nil {
+		return &entities.IncidentResponse{
+			Success: false,
+			Error:   "Failed to report incident",
+		}, err
+	}
+
+	if incident.Severity == "high" || incident.Severity == "critical" {
+		go uc.notifyIncident(incident)
+	}
+
+	return &entities.IncidentResponse{
+		Success:  true,
+		Message:  "Incident reported. Support will follow up",
+		Incident: incident,
+	}, nil
+}
+
+// notifyIncident runs in the background; the incident is already stored and
+// shows up in the ops queue even if the notification fails
+func (uc *IncidentUseCase) notifyIncident(incident *entities.Incident) {
+	message := strings.ReplaceAll(incident.Category, "_", " ")
+	if incident.Description != "" {
+		message += ": " + incident.Description
+	}
+
+	notification := &entities.Notification{
+		Type:       "incident.reported",
+		Severity:   incident.Severity,
+		Audience:   entities.AudienceSupport,
+		Title:      fmt.Sprintf("Incident on order %s", incident.OrderID),
+		Message:    message,
+		DeliveryID: incident.DeliveryID,
+		OrderID:    incident.OrderID,
+		PartnerID:  incident.PartnerID,
+		Data: map[string]string{
+			"incidentId": incident.IncidentID,
+			"category":   incident.Category,
+		},
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.notifier.Notify(notification); err != nil {
+		log.Printf("❌ Failed to send %s notification for incident %s: %v", uc.notifier.Name(), incident.IncidentID, err)
+	}
+}
+
+func (uc *IncidentUseCase) GetDeliveryIncidents(deliveryID, partnerID string) (*entities.GetDeliveryIncidentsResponse, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.GetDeliveryIncidentsResponse{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if delivery.PartnerID != partnerID {
+		return &entities.GetDeliveryIncidentsResponse{
+			Success: false,
+			Error:   "Unauthorized",
+		}, errors.New("unauthorized")
+	}
+
+	incidents, err := uc.incidentRepo.GetByDelivery(deliveryID)
+	if err != nil {
+		return &entities.GetDeliveryIncidentsResponse{
+			Success: false,
+			Error:   "Failed to load incidents",
+		}, err
+	}
+
+	if incidents == nil {
+		incidents = []entities.Incident{}
+	}
+
+	return &entities.GetDeliveryIncidentsResponse{
+		Success:   true,
+		Incidents: incidents,
+		Count:     len(incidents),
+	}, nil
+}
+
+func (uc *IncidentUseCase) GetIncidents(req *entities.GetIncidentsRequest) (*entities.GetIncidentsResponse, error) {
+	filter := &repositories.IncidentFilter{
+		Statuses:   splitList(req.Status),
+		Severities: splitList(req.Severity),
+		Category:   req.Category,
+		PartnerID:  req.PartnerID,
+		DeliveryID: req.DeliveryID,
+	}
+
+	incidents, total, err := uc.incidentRepo.Find(filter, req.Limit, req.Offset)
+	if err != nil {
+		return &entities.GetIncidentsResponse{
+			Success: false,
+		}, err
+	}
+
+	if incidents == nil {
+		incidents = []entities.Incident{}
+	}
+
+	return &entities.GetIncidentsResponse{
+		Success:     true,
+		Incidents:   incidents,
+		Total:       total,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+		HasNext:     (req.Offset + req.Limit) < total,
+		HasPrevious: req.Offset > 0,
+	}, nil
+}
+
+func (uc *IncidentUseCase) AcknowledgeIncident(incidentID, actor string) (*entities.IncidentResponse, error) {
+	if _, err := uc.incidentRepo.GetByID(incidentID); err != nil {
+		return &entities.IncidentResponse{
+			Success: false,
+			Error:   "Incident not found",
+		}, err
+	}
+
+	updated, err := uc.incidentRepo.Acknowledge(incidentID, actor)
+	if err != nil {
+		return &entities.IncidentResponse{
+			Success: false,
+			Error:   "Failed to acknowledge incident",
+		}, err
+	}
+
+	if !updated {
+		return &entities.IncidentResponse{
+			Success: false,
+			Message: "Only open incidents can be acknowledged",
+		}, nil
+	}
+
+	return uc.incidentResult(incidentID, "Incident acknowledged")
+}
+
+func (uc *IncidentUseCase) ResolveIncident(incidentID, actor string, req *entities.ResolveIncidentRequest) (*entities.IncidentResponse, error) {
+	if _, err := uc.incidentRepo.GetByID(incidentID); err != nil {
+		return &entities.IncidentResponse{
+			Success: false,
+			Error:   "Incident not found",
+		}, err
+	}
+
+	updated, err := uc.incidentRepo.Resolve(incidentID, actor, strings.TrimSpace(req.Resolution))
+	if err != nil {
+		return &entities.IncidentResponse{
+			Success: false,
+			Error:   "Failed to resolve incident",
+		}, err
+	}
+
+	if !updated {
+		return &entities.IncidentResponse{
+			Success: false,
+			Message: "Incident is already resolved",
+		}, nil
+	}
+
+	return uc.incidentResult(incidentID, "Incident resolved")
+}
+
+func (uc *IncidentUseCase) incidentResult(incidentID, message string) (*entities.IncidentResponse, error) {
+	incident, err := uc.incidentRepo.GetByID(incidentID)
+	if err != nil {
+		return &entities.IncidentResponse{
+			Success: false,
+			Error:   "Failed to load incident",
+		}, err
+	}
+
+	return &entities.IncidentResponse{
+		Success:  true,
+		Message:  message,
+		Incident: incident,
+	}, nil
+}
+
+// splitList parses a comma separated query value, ignoring blanks
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
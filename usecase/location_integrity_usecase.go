@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/utils"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// minSpeedCheckKm keeps GPS jitter between close fixes from reading as speed
+const minSpeedCheckKm = 0.2
+
+// IntegrityConfig sets the location integrity checks
+type IntegrityConfig struct {
+	MaxSpeedKmh    float64       // faster than this between fixes is impossible
+	TeleportKm     float64       // a jump at least this far...
+	TeleportWindow time.Duration // ...within this long is a teleport
+}
+
+// integrityFinding is one check a fix failed
+type integrityFinding struct {
+	Type       string
+	Details    string
+	DistanceKm *float64
+	SpeedKmh   *float64
+}
+
+// LocationIntegrityUseCase checks incoming fixes for signs of GPS spoofing
+// and keeps the ops review queue
+type LocationIntegrityUseCase struct {
+	flagRepo     repositories.LocationFlagRepository
+	pingRepo     repositories.LocationPingRepository
+	deliveryRepo repositories.DeliveryRepository
+	config       IntegrityConfig
+}
+
+func NewLocationIntegrityUseCase(
+	flagRepo repositories.LocationFlagRepository,
+	pingRepo repositories.LocationPingRepository,
+	deliveryRepo repositories.DeliveryRepository,
+	config IntegrityConfig,
+) *LocationIntegrityUseCase {
+	return &LocationIntegrityUseCase{
+		flagRepo:     flagRepo,
+		pingRepo:     pingRepo,
+		deliveryRepo: deliveryRepo,
+		config:       config,
+	}
+}
+
+// Inspect checks new pings, oldest first, against each other and the last
+// stored fix. Each ping is marked with the checks it failed, and a flag is
+// filed for ops unless the partner already has the same one open. Fixes are
+// never rejected: a flagged fix is evidence, and a false positive shouldn't
+// stop a partner working.
+func (uc *LocationIntegrityUseCase) Inspect(partnerID string, pings []entities.LocationPing) {
+	if len(pings) == 0 {
+		return
+	}
+
+	prev, err := uc.pingRepo.GetLatest(partnerID, pings[0].RecordedAt)
+	if err != nil {
+		log.Printf("❌ Failed to load last location of partner %s: %v", partnerID, err)
+	}
+
+	var deliveryIDs []string
+	filed := map[string]bool{}
+	for i := range pings {
+		ping := &pings[i]
+		findings := checkFix(prev, ping, uc.config)
+		prev = ping
+
+		for _, finding := range findings {
+			ping.Flags = append(ping.Flags, finding.Type)
+			if filed[finding.Type] {
+				continue
+			}
+			filed[finding.Type] = true
+
+			if deliveryIDs == nil {
+				deliveryIDs = uc.heldDeliveries(partnerID)
+			}
+			uc.file(partnerID, deliveryIDs, ping, finding)
+		}
+	}
+}
+
+func (uc *LocationIntegrityUseCase) file(partnerID string, deliveryIDs []string, ping *entities.LocationPing, finding integrityFinding) {
+	open, err := uc.flagRepo.HasOpen(partnerID, finding.Type, deliveryIDs)
+	if err != nil {
+		log.Printf("❌ Failed to check location flags of partner %s: %v", partnerID, err)
+		return
+	}
+	if open {
+		return
+	}
+
+	flag := &entities.LocationFlag{
+		PartnerID:   partnerID,
+		DeliveryIDs: deliveryIDs,
+		Type:        finding.Type,
+		Details:     finding.Details,
+		Latitude:    ping.Latitude,
+		Longitude:   ping.Longitude,
+		DistanceKm:  finding.DistanceKm,
+		SpeedKmh:    finding.SpeedKmh,
+		RecordedAt:  ping.RecordedAt,
+		Status:      "open",
+	}
+	if err := uc.flagRepo.Create(flag); err != nil {
+		log.Printf("❌ Failed to file %s location flag for partner %s: %v", finding.Type, partnerID, err)
+		return
+	}
+	log.Printf("🚩 Location flag %s for partner %s: %s", finding.Type, partnerID, finding.Details)
+}
+
+// heldDeliveries returns the sorted IDs of the orders the partner holds, never nil
+func (uc *LocationIntegrityUseCase) heldDeliveries(partnerID string) []string {
+	ids := []string{}
+	deliveries, err := uc.deliveryRepo.GetActiveOrdersByPartner(partnerID)
+	if err != nil {
+		log.Printf("❌ Failed to load active orders of partner %s: %v", partnerID, err)
+		return ids
+	}
+	for _, d := range deliveries {
+		ids = append(ids, d.DeliveryID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (uc *LocationIntegrityUseCase) GetFlags(req *entities.GetLocationFlagsRequest) (*entities.GetLocationFlagsResponse, error) {
+	filter := &repositories.LocationFlagFilter{
+		Statuses:   splitList(req.Status),
+		Types:      splitList(req.Type),
+		PartnerID:  req.PartnerID,
+		DeliveryID: req.DeliveryID,
+	}
+
+	flags, total, err := uc.flagRepo.Find(filter, req.Limit, req.Offset)
+	if err != nil {
+		return &entities.GetLocationFlagsResponse{
+			Success: false,
+		}, err
+	}
+
+	if flags == nil {
+		flags = []entities.LocationFlag{}
+	}
+
+	return &entities.GetLocationFlagsResponse{
+		Success:     true,
+		Flags:       flags,
+		Total:       total,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+		HasNext:     (req.Offset + req.Limit) < total,
+		HasPrevious: req.Offset > 0,
+	}, nil
+}
+
+// ReviewFlag closes an open flag. Either way it stops counting against the
+// partner and their orders; confirmed flags stay on record.
+func (uc *LocationIntegrityUseCase) ReviewFlag(flagID, actor string, req *entities.ReviewLocationFlagRequest) (*entities.LocationFlagResponse, error) {
+	if _, err := uc.flagRepo.GetByID(flagID); err != nil {
+		return &entities.LocationFlagResponse{
+			Success: false,
+			Error:   "Location flag not found",
+		}, err
+	}
+
+	status := "dismissed"
+	if req.Decision == "confirm" {
+		status = "confirmed"
+	}
+
+	updated, err := uc.flagRepo.Review(flagID, status, actor, strings.TrimSpace(req.Note))
+	if err != nil {
+		return &entities.LocationFlagResponse{
+			Success: false,
+			Error:   "Failed to review location flag",
+		}, err
+	}
+
+	if !updated {
+		return &entities.LocationFlagResponse{
+			Success: false,
+			Message: "Location flag has already been reviewed",
+		}, nil
+	}
+
+	flag, err := uc.flagRepo.GetByID(flagID)
+	if err != nil {
+		return &entities.LocationFlagResponse{
+			Success: false,
+			Error:   "Failed to load location flag",
+		}, err
+	}
+
+	return &entities.LocationFlagResponse{
+		Success: true,
+		Message: "Location flag " + status,
+		Flag:    flag,
+	}, nil
+}
+
+// checkFix returns the integrity checks a fix fails, given the fix before it
+func checkFix(prev, ping *entities.LocationPing, config IntegrityConfig) []integrityFinding {
+	var findings []integrityFinding
+
+	if ping.IsMock {
+		findings = append(findings, integrityFinding{
+			Type:    "mock_location",
+			Details: "the device reported a mock location",
+		})
+	}
+
+	// Real receivers always report some uncertainty; spoofing apps often don't
+	if ping.Accuracy != nil && *ping.Accuracy == 0 {
+		findings = append(findings, integrityFinding{
+			Type:    "zero_accuracy",
+			Details: "accuracy reported as exactly 0 m",
+		})
+	}
+
+	if prev == nil {
+		return findings
+	}
+
+	distance := utils.HaversineKm(prev.Latitude, prev.Longitude, ping.Latitude, ping.Longitude)
+	gap := ping.RecordedAt.Sub(prev.RecordedAt)
+	distanceKm := math.Round(distance*100) / 100
+
+	switch {
+	case distance >= config.TeleportKm && gap <= config.TeleportWindow:
+		findings = append(findings, integrityFinding{
+			Type:       "teleport",
+			Details:    fmt.Sprintf("jumped %.1f km in %s", distance, gap.Round(time.Second)),
+			DistanceKm: &distanceKm,
+		})
+	case distance >= minSpeedCheckKm && gap > 0:
+		speed := distance / gap.Hours()
+		if speed > config.MaxSpeedKmh {
+			speedKmh := math.Round(speed)
+			findings = append(findings, integrityFinding{
+				Type:       "impossible_speed",
+				Details:    fmt.Sprintf("moved %.1f km in %s (%.0f km/h)", distance, gap.Round(time.Second), speed),
+				DistanceKm: &distanceKm,
+				SpeedKmh:   &speedKmh,
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheckFix(t *testing.T) {
+	config := IntegrityConfig{
+		MaxSpeedKmh:    150,
+		TeleportKm:     2,
+		TeleportWindow: 10 * time.Second,
+	}
+	start := time.Date(2025, 10, 26, 10, 0, 0, 0, time.UTC)
+	at := func(kmNorth float64, after time.Duration) *entities.LocationPing {
+		return &entities.LocationPing{
+			Latitude:   testPickupLat + kmNorth/kmPerDegLat,
+			Longitude:  testPickupLng,
+			RecordedAt: start.Add(after),
+		}
+	}
+	zero := 0.0
+	accurate := 8.0
+
+	tests := []struct {
+		name string
+		prev *entities.LocationPing
+		ping *entities.LocationPing
+		want []string
+	}{
+		{"first fix", nil, at(0, 0), nil},
+		{"normal riding", at(0, 0), at(0.5, time.Minute), nil},
+		{"mock location", nil, &entities.LocationPing{IsMock: true}, []string{"mock_location"}},
+		{"zero accuracy", nil, &entities.LocationPing{Accuracy: &zero}, []string{"zero_accuracy"}},
+		{"some accuracy", nil, &entities.LocationPing{Accuracy: &accurate}, nil},
+		{"teleport", at(0, 0), at(3, 5*time.Second), []string{"teleport"}},
+		// 5 km in a minute is 300 km/h
+		{"impossible speed", at(0, 0), at(5, time.Minute), []string{"impossible_speed"}},
+		{"fast but possible", at(0, 0), at(2, time.Minute), nil},
+		// GPS jitter between close fixes isn't speed
+		{"jitter", at(0, 0), at(0.1, time.Second), nil},
+		{"mock and teleport", at(0, 0), func() *entities.LocationPing {
+			p := at(3, 5*time.Second)
+			p.IsMock = true
+			return p
+		}(), []string{"mock_location", "teleport"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range checkFix(tt.prev, tt.ping, config) {
+				got = append(got, f.Type)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkFix = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/utils"
+	"fmt"
+	"log"
+	"time"
+)
+
+// maxTrailPoints caps a delivery trail; a two hour trip pinged every second fits
+const maxTrailPoints = 7200
+
+// clockSkewAllowance is how far ahead of the server a device clock may run
+const clockSkewAllowance = time.Minute
+
+// LocationUseCase records partner locations and serves their history
+type LocationUseCase struct {
+	partnerRepo  repositories.DeliveryPartnerRepository
+	deliveryRepo repositories.DeliveryRepository
+	pingRepo     repositories.LocationPingRepository
+	integrity    *LocationIntegrityUseCase
+	hub          *StreamHub
+	// Pings older than this are past the history's retention, and their
+	// de-duplication claims may already be gone
+	retention time.Duration
+}
+
+func NewLocationUseCase(
+	partnerRepo repositories.DeliveryPartnerRepository,
+	deliveryRepo repositories.DeliveryRepository,
+	pingRepo repositories.LocationPingRepository,
+	integrity *LocationIntegrityUseCase,
+	hub *StreamHub,
+	retention time.Duration,
+) *LocationUseCase {
+	return &LocationUseCase{
+		partnerRepo:  partnerRepo,
+		deliveryRepo: deliveryRepo,
+		pingRepo:     pingRepo,
+		integrity:    integrity,
+		hub:          hub,
+		retention:    retention,
+	}
+}
+
+// UpdateLocation moves the partner and keeps the fix in their location history
+func (uc *LocationUseCase) UpdateLocation(partnerID string, req *entities.UpdateLocationRequest) (*entities.ResponseMessage, error) {
+	now := time.Now()
+	recordedAt := now
+	if req.RecordedAt != nil {
+		if req.RecordedAt.After(now.Add(clockSkewAllowance)) {
+			return &entities.ResponseMessage{
+				Success: false,
+				Message: "recordedAt is in the future",
+			}, nil
+		}
+		recordedAt = *req.RecordedAt
+	}
+
+	// A fix taken while the request was delayed mustn't replace a newer one
+	updated, err := uc.partnerRepo.UpdateLocationIfNewer(partnerID, req.Latitude, req.Longitude, fixTime(recordedAt, now))
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to update location",
+		}, err
+	}
+
+	// History is best effort; the current location is what dispatch needs
+	ping := &entities.LocationPing{
+		PartnerID:  partnerID,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Accuracy:   req.Accuracy,
+		Speed:      req.Speed,
+		Heading:    req.Heading,
+		Battery:    req.Battery,
+		IsMock:     req.IsMock,
+		RecordedAt: recordedAt,
+	}
+	uc.recordPing(ping)
+
+	if !updated {
+		if _, err := uc.partnerRepo.Heartbeat(partnerID); err != nil {
+			log.Printf("❌ Failed to record heartbeat for partner %s: %v", partnerID, err)
+		}
+		return &entities.ResponseMessage{
+			Success: true,
+			Message: "Location saved to history; a newer location is already on file",
+		}, nil
+	}
+	uc.hub.PublishLocation(ping)
+
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: "Location updated successfully",
+	}, nil
+}
+
+// UploadLocationBatch saves fixes the app buffered while it had no signal.
+// Points already stored, from a retried upload say, are skipped, as are
+// points older than the history keeps, and the partner only moves if the
+// newest point is newer than their current location.
+func (uc *LocationUseCase) UploadLocationBatch(partnerID string, req *entities.LocationBatchRequest) (*entities.LocationBatchResponse, error) {
+	points := req.Points
+	for i := 1; i < len(points); i++ {
+		if points[i].RecordedAt.Before(points[i-1].RecordedAt) {
+			return &entities.LocationBatchResponse{
+				Success: false,
+				Message: fmt.Sprintf("Points must be in recordedAt order; point %d is older than point %d", i, i-1),
+			}, nil
+		}
+	}
+
+	newest := points[len(points)-1]
+	if newest.RecordedAt.After(time.Now().Add(clockSkewAllowance)) {
+		return &entities.LocationBatchResponse{
+			Success: false,
+			Message: "recordedAt is in the future",
+		}, nil
+	}
+
+	// Points are oldest first, so the expired ones are a prefix
+	expired := 0
+	cutoff := time.Now().Add(-uc.retention)
+	for expired < len(points) && points[expired].RecordedAt.Before(cutoff) {
+		expired++
+	}
+	points = points[expired:]
+	if len(points) == 0 {
+		// Old points still show the app is alive
+		if _, err := uc.partnerRepo.Heartbeat(partnerID); err != nil {
+			log.Printf("❌ Failed to record heartbeat for partner %s: %v", partnerID, err)
+		}
+		return &entities.LocationBatchResponse{
+			Success: true,
+			Message: "Locations are older than the history keeps",
+			Expired: expired,
+		}, nil
+	}
+
+	recordedAts := make([]time.Time, len(points))
+	for i := range points {
+		recordedAts[i] = points[i].RecordedAt
+	}
+	claimed, err := uc.pingRepo.Claim(partnerID, recordedAts)
+	if err != nil {
+		return &entities.LocationBatchResponse{
+			Success: false,
+			Error:   "Failed to save locations",
+		}, err
+	}
+
+	// Claims keep milliseconds, so match at that precision
+	fresh := make(map[int64]bool, len(claimed))
+	for _, recordedAt := range claimed {
+		fresh[recordedAt.UnixMilli()] = true
+	}
+
+	pings := make([]entities.LocationPing, 0, len(claimed))
+	for i := range points {
+		key := points[i].RecordedAt.UnixMilli()
+		if !fresh[key] {
+			continue
+		}
+		delete(fresh, key)
+		pings = append(pings, pointPing(partnerID, &points[i]))
+	}
+
+	uc.integrity.Inspect(partnerID, pings)
+
+	if err := uc.pingRepo.InsertMany(pings); err != nil {
+		if err := uc.pingRepo.Release(partnerID, claimed); err != nil {
+			log.Printf("❌ Failed to release location ping claims for partner %s: %v", partnerID, err)
+		}
+		return &entities.LocationBatchResponse{
+			Success: false,
+			Error:   "Failed to save locations",
+		}, err
+	}
+
+	updated, err := uc.partnerRepo.UpdateLocationIfNewer(partnerID, newest.Latitude, newest.Longitude, fixTime(newest.RecordedAt, time.Now()))
+	if err != nil {
+		return &entities.LocationBatchResponse{
+			Success: false,
+			Error:   "Failed to update location",
+		}, err
+	}
+	if updated {
+		ping := pointPing(partnerID, &newest)
+		ping.ReceivedAt = time.Now()
+		uc.hub.PublishLocation(&ping)
+	} else if _, err := uc.partnerRepo.Heartbeat(partnerID); err != nil {
+		// Old points still show the app is alive
+		log.Printf("❌ Failed to record heartbeat for partner %s: %v", partnerID, err)
+	}
+
+	return &entities.LocationBatchResponse{
+		Success:         true,
+		Message:         "Locations saved",
+		Accepted:        len(pings),
+		Duplicates:      len(points) - len(pings),
+		Expired:         expired,
+		LocationUpdated: updated,
+	}, nil
+}
+
+// recordPing inspects a live fix and keeps it in the history, unless a
+// retried request already did or it is older than the history keeps
+func (uc *LocationUseCase) recordPing(ping *entities.LocationPing) {
+	if ping.RecordedAt.Before(time.Now().Add(-uc.retention)) {
+		return
+	}
+
+	recordedAt := []time.Time{ping.RecordedAt}
+	claimed, err := uc.pingRepo.Claim(ping.PartnerID, recordedAt)
+	if err != nil {
+		log.Printf("❌ Failed to record location ping for partner %s: %v", ping.PartnerID, err)
+		return
+	}
+	if len(claimed) == 0 {
+		return
+	}
+
+	pings := []entities.LocationPing{*ping}
+	uc.integrity.Inspect(ping.PartnerID, pings)
+	ping.Flags = pings[0].Flags
+
+	if err := uc.pingRepo.Insert(ping); err != nil {
+		log.Printf("❌ Failed to record location ping for partner %s: %v", ping.PartnerID, err)
+		if err := uc.pingRepo.Release(ping.PartnerID, recordedAt); err != nil {
+			log.Printf("❌ Failed to release location ping claims for partner %s: %v", ping.PartnerID, err)
+		}
+	}
+}
+
+// GetPartnerLocations returns a partner's pings in a time range
+func (uc *LocationUseCase) GetPartnerLocations(partnerID string, req *entities.GetPartnerLocationsRequest) (*entities.GetPartnerLocationsResponse, error) {
+	to := req.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	pings, err := uc.pingRepo.GetTrail(partnerID, req.From, to, req.Limit)
+	if err != nil {
+		return &entities.GetPartnerLocationsResponse{
+			Success: false,
+			Error:   "Failed to load locations",
+		}, err
+	}
+	if pings == nil {
+		pings = []entities.LocationPing{}
+	}
+
+	return &entities.GetPartnerLocationsResponse{
+		Success:   true,
+		PartnerID: partnerID,
+		Points:    pings,
+		Count:     len(pings),
+	}, nil
+}
+
+// GetDeliveryTrail returns the breadcrumb trail of a delivery from pickup to
+// drop, or up to now while it's still on the road
+func (uc *LocationUseCase) GetDeliveryTrail(deliveryID string) (*entities.GetDeliveryTrailResponse, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.GetDeliveryTrailResponse{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	if delivery.PickedUpAt == nil || delivery.PartnerID == "" {
+		return &entities.GetDeliveryTrailResponse{
+			Success:    false,
+			Message:    "Order has not been picked up yet",
+			DeliveryID: deliveryID,
+			Points:     []entities.LocationPing{},
+		}, nil
+	}
+
+	from := *delivery.PickedUpAt
+	to := time.Now()
+	if delivery.DeliveredAt != nil {
+		to = *delivery.DeliveredAt
+	}
+
+	pings, err := uc.pingRepo.GetTrail(delivery.PartnerID, from, to, maxTrailPoints)
+	if err != nil {
+		return &entities.GetDeliveryTrailResponse{
+			Success: false,
+			Error:   "Failed to load trail",
+		}, err
+	}
+	if pings == nil {
+		pings = []entities.LocationPing{}
+	}
+
+	return &entities.GetDeliveryTrailResponse{
+		Success:    true,
+		DeliveryID: deliveryID,
+		PartnerID:  delivery.PartnerID,
+		From:       &from,
+		To:         &to,
+		Points:     pings,
+		Count:      len(pings),
+		DistanceKm: trailDistanceKm(pings),
+	}, nil
+}
+
+// fixTime puts a fix on the server's clock for lastLocationAt: a device clock
+// running ahead is clamped to now, so a live ping and a later batch compare
+// like with like and a fast device can't hold off newer fixes
+func fixTime(recordedAt, now time.Time) time.Time {
+	if recordedAt.After(now) {
+		return now
+	}
+	return recordedAt
+}
+
+func pointPing(partnerID string, point *entities.LocationPoint) entities.LocationPing {
+	return entities.LocationPing{
+		PartnerID:  partnerID,
+		Latitude:   point.Latitude,
+		Longitude:  point.Longitude,
+		Accuracy:   point.Accuracy,
+		Speed:      point.Speed,
+		Heading:    point.Heading,
+		Battery:    point.Battery,
+		IsMock:     point.IsMock,
+		RecordedAt: point.RecordedAt,
+	}
+}
+
+// trailDistanceKm is the straight-line distance along consecutive pings
+func trailDistanceKm(pings []entities.LocationPing) float64 {
+	total := 0.0
+	for i := 1; i < len(pings); i++ {
+		total += utils.HaversineKm(pings[i-1].Latitude, pings[i-1].Longitude, pings[i].Latitude, pings[i].Longitude)
+	}
+	return total
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/utils"
+	"errors"
+	"sort"
+	"time"
+)
+
+// In-memory repositories for use case tests. Each embeds its interface, so a
+// method the tests don't implement panics instead of quietly doing nothing.
+
+var errNotFound = errors.New("not found")
+
+type memoryDeliveryRepo struct {
+	repositories.DeliveryRepository
+	deliveries map[string]*entities.Delivery
+}
+
+func newMemoryDeliveryRepo(deliveries ...entities.Delivery) *memoryDeliveryRepo {
+	r := &memoryDeliveryRepo{deliveries: map[string]*entities.Delivery{}}
+	for i := range deliveries {
+		d := deliveries[i]
+		r.deliveries[d.DeliveryID] = &d
+	}
+	return r
+}
+
+func (r *memoryDeliveryRepo) GetByID(deliveryID string) (*entities.Delivery, error) {
+	d, ok := r.deliveries[deliveryID]
+	if !ok {
+		return nil, errNotFound
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *memoryDeliveryRepo) GetPendingOrders(windowOpensBefore time.Time) ([]entities.Delivery, error) {
+	var pending []entities.Delivery
+	for _, d := range r.deliveries {
+		if d.Status == "pending" && (d.WindowStart == nil || d.WindowStart.Before(windowOpensBefore)) {
+			pending = append(pending, *d)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].DeliveryID < pending[j].DeliveryID })
+	return pending, nil
+}
+
+func (r *memoryDeliveryRepo) GetActiveOrdersByPartners(partnerIDs []string) (map[string][]entities.Delivery, error) {
+	wanted := make(map[string]bool, len(partnerIDs))
+	for _, id := range partnerIDs {
+		wanted[id] = true
+	}
+
+	active := map[string][]entities.Delivery{}
+	for _, d := range r.deliveries {
+		switch d.Status {
+		case "pending", "assigned", "picked_up", "in_transit":
+			if d.PartnerID != "" && wanted[d.PartnerID] {
+				active[d.PartnerID] = append(active[d.PartnerID], *d)
+			}
+		}
+	}
+	return active, nil
+}
+
+type memoryPartnerRepo struct {
+	repositories.DeliveryPartnerRepository
+	partners []entities.DeliveryPartner
+}
+
+func (r *memoryPartnerRepo) FindAvailablePartnersNear(point entities.GeoPoint, radiusKm float64, locatedSince time.Time, limit int) ([]entities.DeliveryPartner, error) {
+	lat, lng := point.Latitude(), point.Longitude()
+	var nearby []entities.DeliveryPartner
+	for _, p := range r.partners {
+		if !p.IsAvailable || p.LastLocationAt.Before(locatedSince) {
+			continue
+		}
+		if utils.HaversineKm(lat, lng, p.CurrentLatitude, p.CurrentLongitude) <= radiusKm {
+			nearby = append(nearby, p)
+		}
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return utils.HaversineKm(lat, lng, nearby[i].CurrentLatitude, nearby[i].CurrentLongitude) <
+			utils.HaversineKm(lat, lng, nearby[j].CurrentLatitude, nearby[j].CurrentLongitude)
+	})
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby, nil
+}
+
+type memoryOfferRepo struct {
+	repositories.OfferRepository
+	offers []entities.Offer
+}
+
+func (r *memoryOfferRepo) CreateMany(offers []entities.Offer) error {
+	now := time.Now()
+	for _, o := range offers {
+		o.CreatedAt = now
+		r.offers = append(r.offers, o)
+	}
+	return nil
+}
+
+func (r *memoryOfferRepo) ExpireDue(now time.Time) (int, error) {
+	expired := 0
+	for i := range r.offers {
+		if r.offers[i].Status == "pending" && r.offers[i].ExpiresAt.Before(now) {
+			r.offers[i].Status = "expired"
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func (r *memoryOfferRepo) GetDeliveriesWithPendingOffers(deliveryIDs []string) (map[string]bool, error) {
+	offered := map[string]bool{}
+	for _, o := range r.offers {
+		if o.Status == "pending" {
+			offered[o.DeliveryID] = true
+		}
+	}
+	return offered, nil
+}
+
+func (r *memoryOfferRepo) CountPendingByPartners(partnerIDs []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, o := range r.offers {
+		if o.Status == "pending" {
+			counts[o.PartnerID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *memoryOfferRepo) GetOfferedPartners(deliveryID string, since time.Time) ([]string, error) {
+	var partnerIDs []string
+	for _, o := range r.offers {
+		if o.DeliveryID == deliveryID && !o.CreatedAt.Before(since) {
+			partnerIDs = append(partnerIDs, o.PartnerID)
+		}
+	}
+	return partnerIDs, nil
+}
+
+func (r *memoryOfferRepo) pendingFor(deliveryID string) []string {
+	var partnerIDs []string
+	for _, o := range r.offers {
+		if o.DeliveryID == deliveryID && o.Status == "pending" {
+			partnerIDs = append(partnerIDs, o.PartnerID)
+		}
+	}
+	return partnerIDs
+}
+
+type memoryZoneRepo struct {
+	repositories.ZoneRepository
+	zones []entities.Zone
+}
+
+func (r *memoryZoneRepo) GetByWarehouse(warehouseID string) ([]entities.Zone, error) {
+	var zones []entities.Zone
+	for _, z := range r.zones {
+		for _, id := range z.WarehouseIDs {
+			if id == warehouseID {
+				zones = append(zones, z)
+			}
+		}
+	}
+	return zones, nil
+}
+
+func (r *memoryZoneRepo) FindContaining(point entities.GeoPoint) ([]entities.Zone, error) {
+	var zones []entities.Zone
+	for _, z := range r.zones {
+		if utils.PointInRing(point.Latitude(), point.Longitude(), z.Area.Coordinates[0]) {
+			zones = append(zones, z)
+		}
+	}
+	return zones, nil
+}
+
+// memoryAssigner assigns straight into the delivery repo, without the
+// capacity check the real one runs in its transaction
+type memoryAssigner struct {
+	deliveryRepo *memoryDeliveryRepo
+}
+
+func (a *memoryAssigner) AssignOrder(delivery *entities.Delivery, partner *entities.DeliveryPartner) error {
+	d, ok := a.deliveryRepo.deliveries[delivery.DeliveryID]
+	if !ok {
+		return errNotFound
+	}
+	d.PartnerID = partner.PartnerID
+	d.Status = "assigned"
+	return nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"log"
+	"strings"
+	"time"
+)
+
+type OfferUseCase struct {
+	offerRepo    repositories.OfferRepository
+	deliveryRepo repositories.DeliveryRepository
+	dispatcher   Dispatcher
+}
+
+func NewOfferUseCase(
+	offerRepo repositories.OfferRepository,
+	deliveryRepo repositories.DeliveryRepository,
+	dispatcher Dispatcher,
+) *OfferUseCase {
+	return &OfferUseCase{
+		offerRepo:    offerRepo,
+		deliveryRepo: deliveryRepo,
+		dispatcher:   dispatcher,
+	}
+}
+
+// GetOffers lists the partner's open offers, soonest expiry first
+func (uc *OfferUseCase) GetOffers(partnerID string) (*entities.GetOffersResponse, error) {
+	offers, err := uc.offerRepo.GetPendingByPartner(partnerID)
+	if err != nil {
+		return &entities.GetOffersResponse{
+			Success: false,
+		}, err
+	}
+
+	now := time.Now()
+	items := make([]entities.OfferListItem, 0, len(offers))
+	for _, o := range offers {
+		// Expired offers are closed by the next dispatch pass
+		if now.After(o.ExpiresAt) {
+			continue
+		}
+
+		delivery, err := uc.deliveryRepo.GetByID(o.DeliveryID)
+		if err != nil {
+			continue
+		}
+
+		items = append(items, entities.OfferListItem{
+			OfferID:         o.OfferID,
+			DeliveryID:      o.DeliveryID,
+			OrderID:         o.OrderID,
+			PickupAddress:   delivery.PickupAddress,
+			DeliveryAddress: delivery.DeliveryAddress,
+			Distance:        delivery.Distance,
+			DeliveryFee:     delivery.DeliveryFee,
+			ItemsCount:      delivery.ItemsCount,
+			WindowStart:     delivery.WindowStart,
+			WindowEnd:       delivery.WindowEnd,
+			ExpiresAt:       o.ExpiresAt,
+		})
+	}
+
+	return &entities.GetOffersResponse{
+		Success: true,
+		Offers:  items,
+		Count:   len(items),
+	}, nil
+}
+
+// RejectOrder declines the partner's offer for a delivery. Once nobody else
+// holds an open offer the order goes to the next candidate.
+func (uc *OfferUseCase) RejectOrder(deliveryID, partnerID string, req *entities.RejectOrderRequest) (*entities.ResponseMessage, error) {
+	offers, err := uc.offerRepo.GetPendingByDelivery(deliveryID)
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to load offers",
+		}, err
+	}
+
+	var offer *entities.Offer
+	for i := range offers {
+		if offers[i].PartnerID == partnerID {
+			offer = &offers[i]
+		}
+	}
+
+	if offer == nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "You have no open offer for this order",
+		}, nil
+	}
+
+	rejected, err := uc.offerRepo.Respond(offer.OfferID, "rejected", strings.TrimSpace(req.Reason))
+	if err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to reject order",
+		}, err
+	}
+
+	if !rejected {
+		return &entities.ResponseMessage{
+			Success: false,
+			Message: "This offer is no longer open",
+		}, nil
+	}
+
+	if len(offers) == 1 {
+		if _, err := uc.dispatcher.Redispatch(deliveryID, nil); err != nil {
+			log.Printf("❌ Failed to re-offer delivery %s: %v", deliveryID, err)
+		}
+	}
+
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: "Order rejected",
+	}, nil
+}
+
+func (uc *OfferUseCase) GetOfferStats(partnerID, period string) (*entities.GetOfferStatsResponse, error) {
+	if period == "" {
+		period = "week"
+	}
+
+	stats, err := uc.offerRepo.GetStatsByPartner(partnerID, period)
+	if err != nil {
+		return &entities.GetOfferStatsResponse{
+			Success: false,
+		}, err
+	}
+
+	// Withdrawn and still pending offers never got a decision from the partner
+	decided := stats.Accepted + stats.Rejected + stats.Expired
+	if decided > 0 {
+		stats.AcceptanceRate = float64(stats.Accepted) / float64(decided)
+		stats.RejectionRate = float64(stats.Rejected) / float64(decided)
+	}
+
+	return &entities.GetOfferStatsResponse{
+		Success: true,
+		Stats:   stats,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"deliveryAppBackend/domain/repositories"
+	"log"
+	"time"
+)
+
+const (
+	outboxBatchSize = 100
+	outboxLease     = time.Minute
+)
+
+// OutboxRelay publishes events that were committed to the outbox together with
+// the delivery change that produced them.
+type OutboxRelay struct {
+	outboxRepo repositories.OutboxRepository
+	publisher  EventPublisher
+}
+
+func NewOutboxRelay(outboxRepo repositories.OutboxRepository, publisher EventPublisher) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+	}
+}
+
+// Run relays pending outbox messages until the context is cancelled
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.ProcessPending(); err != nil {
+				log.Printf("❌ Outbox relay failed: %v", err)
+			}
+		}
+	}
+}
+
+func (r *OutboxRelay) ProcessPending() error {
+	messages, err := r.outboxRepo.ClaimPending(outboxBatchSize, outboxLease)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		m := &messages[i]
+		if err := r.publisher.Publish(&m.Event); err != nil {
+			attempts := m.Attempts + 1
+			r.outboxRepo.MarkFailed(m.MessageID, attempts, time.Now().Add(retryBackoff(attempts)), err.Error())
+			continue
+		}
+		if err := r.outboxRepo.MarkPublished(m.MessageID); err != nil {
+			log.Printf("❌ Failed to mark outbox message %s published: %v", m.MessageID, err)
+		}
+	}
+
+	return nil
+}
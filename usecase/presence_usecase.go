@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"log"
+	"time"
+)
+
+// PresenceConfig sets how long a partner may go without a heartbeat or
+// location ping before they are stale (no new offers) or offline (taken off
+// the road). Partners holding orders go no further than stale.
+type PresenceConfig struct {
+	StaleAfter   time.Duration
+	OfflineAfter time.Duration
+}
+
+// DefaultPresenceConfig is used when the configured thresholds make no sense
+var DefaultPresenceConfig = PresenceConfig{
+	StaleAfter:   2 * time.Minute,
+	OfflineAfter: 10 * time.Minute,
+}
+
+// defaultPresenceSweepInterval is used when Run is given no usable interval
+const defaultPresenceSweepInterval = 30 * time.Second
+
+// PresenceUseCase keeps partner presence in step with heartbeats
+type PresenceUseCase struct {
+	partnerRepo  repositories.DeliveryPartnerRepository
+	deliveryRepo repositories.DeliveryRepository
+	config       PresenceConfig
+}
+
+func NewPresenceUseCase(partnerRepo repositories.DeliveryPartnerRepository, deliveryRepo repositories.DeliveryRepository, config PresenceConfig) *PresenceUseCase {
+	// A partner must go stale before going offline, or stale would never show
+	if config.StaleAfter <= 0 || config.OfflineAfter <= config.StaleAfter {
+		log.Printf("⚠️  Presence offline threshold (%s) must be longer than the stale one (%s); using %s and %s",
+			config.OfflineAfter, config.StaleAfter, DefaultPresenceConfig.OfflineAfter, DefaultPresenceConfig.StaleAfter)
+		config = DefaultPresenceConfig
+	}
+	return &PresenceUseCase{
+		partnerRepo:  partnerRepo,
+		deliveryRepo: deliveryRepo,
+		config:       config,
+	}
+}
+
+// Run sweeps presence until the context is cancelled
+func (uc *PresenceUseCase) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Printf("⚠️  Presence sweep interval %s is not positive; using %s", interval, defaultPresenceSweepInterval)
+		interval = defaultPresenceSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.Sweep(); err != nil {
+				log.Printf("❌ Presence sweep failed: %v", err)
+			}
+		}
+	}
+}
+
+func (uc *PresenceUseCase) Sweep() error {
+	// Partners out on a delivery stay on the road; the watchdog decides about their orders
+	holdingOrders, err := uc.deliveryRepo.FindPartnersHoldingOrders()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	sweep, err := uc.partnerRepo.SweepPresence(now.Add(-uc.config.StaleAfter), now.Add(-uc.config.OfflineAfter), holdingOrders)
+	if err != nil {
+		return err
+	}
+
+	if sweep.Stale > 0 || sweep.Online > 0 || sweep.Offline > 0 {
+		log.Printf("📡 Presence sweep: %d stale, %d back online, %d offline", sweep.Stale, sweep.Online, sweep.Offline)
+	}
+	return nil
+}
+
+// Heartbeat records that the partner's app is alive
+func (uc *PresenceUseCase) Heartbeat(partnerID string) (*entities.PresenceResponse, error) {
+	partner, err := uc.partnerRepo.Heartbeat(partnerID)
+	if err != nil {
+		return &entities.PresenceResponse{
+			Success: false,
+			Error:   "Failed to record heartbeat",
+		}, err
+	}
+
+	presence, err := uc.presenceOf(partner)
+	if err != nil {
+		return &entities.PresenceResponse{
+			Success: false,
+			Error:   "Failed to load presence",
+		}, err
+	}
+
+	return &entities.PresenceResponse{
+		Success:  true,
+		Presence: presence,
+	}, nil
+}
+
+// SetBreak pauses or resumes offers without going offline
+func (uc *PresenceUseCase) SetBreak(partnerID string, req *entities.SetBreakRequest) (*entities.PresenceResponse, error) {
+	updated, err := uc.partnerRepo.SetBreak(partnerID, req.OnBreak)
+	if err != nil {
+		return &entities.PresenceResponse{
+			Success: false,
+			Error:   "Failed to update break",
+		}, err
+	}
+
+	if !updated {
+		return &entities.PresenceResponse{
+			Success:  false,
+			Message:  "Go online first",
+			Presence: entities.PresenceOffline,
+		}, nil
+	}
+
+	if req.OnBreak {
+		return &entities.PresenceResponse{
+			Success:  true,
+			Message:  "Enjoy your break. You won't get new orders until you're back",
+			Presence: entities.PresenceOnBreak,
+		}, nil
+	}
+
+	return &entities.PresenceResponse{
+		Success:  true,
+		Message:  "Welcome back",
+		Presence: entities.PresenceOnline,
+	}, nil
+}
+
+func (uc *PresenceUseCase) presenceOf(partner *entities.DeliveryPartner) (string, error) {
+	return presenceOf(uc.deliveryRepo, partner)
+}
+
+// presenceOf works out the presence shown for a single partner
+func presenceOf(deliveryRepo repositories.DeliveryRepository, partner *entities.DeliveryPartner) (string, error) {
+	counts, err := deliveryRepo.CountActiveOrdersByPartners([]string{partner.PartnerID})
+	if err != nil {
+		return "", err
+	}
+	return partnerPresence(partner, counts[partner.PartnerID]), nil
+}
+
+// partnerPresence is the state shown for a partner: the stored presence,
+// with online split into busy and online by whether they hold any orders
+func partnerPresence(partner *entities.DeliveryPartner, activeOrders int) string {
+	if !partner.IsAvailable {
+		return entities.PresenceOffline
+	}
+	switch partner.Presence {
+	case entities.PresenceOnBreak, entities.PresenceStale:
+		return partner.Presence
+	}
+	if activeOrders > 0 {
+		return entities.PresenceBusy
+	}
+	return entities.PresenceOnline
+}
+
+// takesOffers reports whether dispatch may offer the partner new orders
+func takesOffers(partner *entities.DeliveryPartner) bool {
+	return partner.IsAvailable && partner.Presence != entities.PresenceOnBreak && partner.Presence != entities.PresenceStale
+}
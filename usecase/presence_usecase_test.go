@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"testing"
+)
+
+func TestPartnerPresence(t *testing.T) {
+	tests := []struct {
+		name         string
+		available    bool
+		stored       string
+		activeOrders int
+		want         string
+	}{
+		{"offline", false, entities.PresenceOffline, 0, entities.PresenceOffline},
+		{"offline with orders", false, entities.PresenceOnline, 2, entities.PresenceOffline},
+		{"online", true, entities.PresenceOnline, 0, entities.PresenceOnline},
+		{"busy", true, entities.PresenceOnline, 1, entities.PresenceBusy},
+		{"on break with orders", true, entities.PresenceOnBreak, 1, entities.PresenceOnBreak},
+		{"stale with orders", true, entities.PresenceStale, 1, entities.PresenceStale},
+		// Partners from before presence was stored
+		{"no stored presence", true, "", 0, entities.PresenceOnline},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partner := &entities.DeliveryPartner{IsAvailable: tt.available, Presence: tt.stored}
+			if got := partnerPresence(partner, tt.activeOrders); got != tt.want {
+				t.Errorf("partnerPresence = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,20 +3,35 @@ package usecase
 import (
 	"deliveryAppBackend/domain/entities"
 	"deliveryAppBackend/domain/repositories"
+	"log"
 )
 
 type ProfileUseCase struct {
-	partnerRepo repositories.DeliveryPartnerRepository
+	partnerRepo  repositories.DeliveryPartnerRepository
+	deliveryRepo repositories.DeliveryRepository
 }
 
-func NewProfileUseCase(partnerRepo repositories.DeliveryPartnerRepository) *ProfileUseCase {
+func NewProfileUseCase(partnerRepo repositories.DeliveryPartnerRepository, deliveryRepo repositories.DeliveryRepository) *ProfileUseCase {
 	return &ProfileUseCase{
-		partnerRepo: partnerRepo,
+		partnerRepo:  partnerRepo,
+		deliveryRepo: deliveryRepo,
 	}
 }
 
 func (uc *ProfileUseCase) GetProfile(partnerID string) (*entities.DeliveryPartner, error) {
-	return uc.partnerRepo.FindByID(partnerID)
+	partner, err := uc.partnerRepo.FindByID(partnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Show presence as ops see it, busy included; the stored one will do if that fails
+	presence, err := presenceOf(uc.deliveryRepo, partner)
+	if err != nil {
+		log.Printf("❌ Failed to load presence of partner %s: %v", partnerID, err)
+	} else {
+		partner.Presence = presence
+	}
+	return partner, nil
 }
 
 func (uc *ProfileUseCase) UpdateProfile(partnerID string, req *entities.UpdateProfileRequest) (*entities.ResponseMessage, error) {
@@ -63,20 +78,6 @@ func (uc *ProfileUseCase) UpdateProfile(partnerID string, req *entities.UpdatePr
 	}, nil
 }
 
-func (uc *ProfileUseCase) UpdateLocation(partnerID string, req *entities.UpdateLocationRequest) (*entities.ResponseMessage, error) {
-	if err := uc.partnerRepo.UpdateLocation(partnerID, req.Latitude, req.Longitude); err != nil {
-		return &entities.ResponseMessage{
-			Success: false,
-			Error:   "Failed to update location",
-		}, err
-	}
-
-	return &entities.ResponseMessage{
-		Success: true,
-		Message: "Location updated successfully",
-	}, nil
-}
-
 func (uc *ProfileUseCase) ToggleAvailability(partnerID string, req *entities.ToggleAvailabilityRequest) (*entities.ResponseMessage, error) {
 	if err := uc.partnerRepo.ToggleAvailability(partnerID, req.IsAvailable); err != nil {
 		return &entities.ResponseMessage{
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"time"
+)
+
+const (
+	// Time spent handing over a parcel at the door
+	dropServiceTime = 3 * time.Minute
+	// A minute past a customer's window costs as much as this many minutes of driving
+	lateMinuteWeight = 10
+	maxTwoOptPasses  = 50
+)
+
+// plannedStop is a pickup or drop in a partner's run. point indexes the
+// distance matrix, where 0 is the partner's position.
+type plannedStop struct {
+	delivery *entities.Delivery
+	kind     string // pickup, drop
+	point    int
+	// For a drop whose pickup is on the same run, the index of that pickup; -1 otherwise
+	pickup int
+}
+
+type stopSchedule struct {
+	arrival time.Time
+	wait    time.Duration
+	late    time.Duration
+	legKm   float64
+}
+
+// routePlanner orders the stops of a multi-drop run: nearest neighbour for a
+// first sequence, then 2-opt until no reversal improves it. A drop never comes
+// before its pickup, partners wait for windows to open, and arriving late is
+// penalised well above extra driving.
+type routePlanner struct {
+	stops    []plannedStop
+	matrix   *entities.RouteMatrix
+	speedKmh float64
+	start    time.Time
+}
+
+func (p *routePlanner) plan() ([]int, []stopSchedule, time.Time) {
+	order := p.nearestNeighbour()
+	best, _, _, _ := p.evaluate(order)
+
+	improved := true
+	for pass := 0; improved && pass < maxTwoOptPasses; pass++ {
+		improved = false
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				candidate := reverseSegment(order, i, j)
+				cost, _, _, ok := p.evaluate(candidate)
+				if ok && cost < best {
+					order, best, improved = candidate, cost, true
+				}
+			}
+		}
+	}
+
+	_, schedule, finish, _ := p.evaluate(order)
+	return order, schedule, finish
+}
+
+// nearestNeighbour repeatedly goes to the stop that can be served soonest
+func (p *routePlanner) nearestNeighbour() []int {
+	order := make([]int, 0, len(p.stops))
+	visited := make([]bool, len(p.stops))
+	at, now := 0, p.start
+
+	for len(order) < len(p.stops) {
+		next, nextReady := -1, time.Time{}
+		for i, stop := range p.stops {
+			if visited[i] || (stop.pickup >= 0 && !visited[stop.pickup]) {
+				continue
+			}
+			ready := now.Add(p.travel(at, stop.point))
+			if stop.kind == "drop" && stop.delivery.WindowStart != nil && ready.Before(*stop.delivery.WindowStart) {
+				ready = *stop.delivery.WindowStart
+			}
+			if next < 0 || ready.Before(nextReady) {
+				next, nextReady = i, ready
+			}
+		}
+
+		visited[next] = true
+		order = append(order, next)
+		at, now = p.stops[next].point, nextReady.Add(p.serviceTime(p.stops[next]))
+	}
+
+	return order
+}
+
+// evaluate simulates the run and returns its cost in minutes, the schedule per
+// position, when the last stop is done, and false if a drop precedes its pickup
+func (p *routePlanner) evaluate(order []int) (float64, []stopSchedule, time.Time, bool) {
+	schedule := make([]stopSchedule, len(order))
+	picked := make([]bool, len(p.stops))
+	at, now := 0, p.start
+	late := time.Duration(0)
+
+	for pos, i := range order {
+		stop := p.stops[i]
+		if stop.pickup >= 0 && !picked[stop.pickup] {
+			return 0, nil, time.Time{}, false
+		}
+
+		now = now.Add(p.travel(at, stop.point))
+		s := stopSchedule{
+			arrival: now,
+			legKm:   p.matrix.DistancesKm[at][stop.point],
+		}
+
+		if stop.kind == "drop" {
+			if ws := stop.delivery.WindowStart; ws != nil && now.Before(*ws) {
+				s.wait = ws.Sub(now)
+				now = *ws
+			}
+			if deadline := stopDeadline(stop.delivery); deadline != nil && now.After(*deadline) {
+				s.late = now.Sub(*deadline)
+				late += s.late
+			}
+		} else {
+			picked[i] = true
+		}
+
+		now = now.Add(p.serviceTime(stop))
+		schedule[pos] = s
+		at = stop.point
+	}
+
+	cost := now.Sub(p.start).Minutes() + lateMinuteWeight*late.Minutes()
+	return cost, schedule, now, true
+}
+
+func (p *routePlanner) travel(from, to int) time.Duration {
+	return time.Duration(p.matrix.DistancesKm[from][to] / p.speedKmh * float64(time.Hour))
+}
+
+func (p *routePlanner) serviceTime(stop plannedStop) time.Duration {
+	if stop.kind == "pickup" {
+		return pickupHandoverTime
+	}
+	return dropServiceTime
+}
+
+// stopDeadline is when the customer expects the order: the end of their
+// window, or the SLA deadline for deliver-now orders
+func stopDeadline(delivery *entities.Delivery) *time.Time {
+	if delivery.WindowEnd != nil {
+		return delivery.WindowEnd
+	}
+	return delivery.SLADeadline
+}
+
+func reverseSegment(order []int, i, j int) []int {
+	reversed := make([]int, len(order))
+	copy(reversed, order)
+	for ; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	return reversed
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// lineMatrix is the distance matrix of points on a straight road, km from the partner
+func lineMatrix(positions ...float64) *entities.RouteMatrix {
+	points := append([]float64{0}, positions...)
+	distances := make([][]float64, len(points))
+	for i := range points {
+		distances[i] = make([]float64, len(points))
+		for j := range points {
+			distances[i][j] = math.Abs(points[i] - points[j])
+		}
+	}
+	return &entities.RouteMatrix{DistancesKm: distances}
+}
+
+func TestRoutePlannerPutsUrgentDropsFirst(t *testing.T) {
+	start := time.Date(2025, 10, 26, 10, 0, 0, 0, time.UTC)
+	relaxed := start.Add(time.Hour)
+	urgent := start.Add(5 * time.Minute)
+
+	// Nearest neighbour drops the relaxed order a kilometre away first, which
+	// makes the urgent one three kilometres the other way late
+	planner := &routePlanner{
+		stops: []plannedStop{
+			{delivery: &entities.Delivery{DeliveryID: "relaxed", SLADeadline: &relaxed}, kind: "drop", point: 1, pickup: -1},
+			{delivery: &entities.Delivery{DeliveryID: "urgent", SLADeadline: &urgent}, kind: "drop", point: 2, pickup: -1},
+		},
+		matrix:   lineMatrix(1, -3),
+		speedKmh: 60,
+		start:    start,
+	}
+
+	if got := planner.nearestNeighbour(); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Fatalf("nearestNeighbour = %v, want [0 1]", got)
+	}
+
+	order, schedule, _ := planner.plan()
+	if !reflect.DeepEqual(order, []int{1, 0}) {
+		t.Fatalf("plan = %v, want [1 0]", order)
+	}
+	for pos, s := range schedule {
+		if s.late > 0 {
+			t.Errorf("stop %d is %s late", pos, s.late)
+		}
+	}
+}
+
+func TestRoutePlannerKeepsPickupBeforeDrop(t *testing.T) {
+	start := time.Date(2025, 10, 26, 10, 0, 0, 0, time.UTC)
+	delivery := &entities.Delivery{DeliveryID: "d1"}
+
+	// The drop is nearer than the pickup, but can't come first
+	planner := &routePlanner{
+		stops: []plannedStop{
+			{delivery: delivery, kind: "drop", point: 1, pickup: 1},
+			{delivery: delivery, kind: "pickup", point: 2, pickup: -1},
+		},
+		matrix:   lineMatrix(1, 4),
+		speedKmh: 30,
+		start:    start,
+	}
+
+	order, schedule, finish := planner.plan()
+	if !reflect.DeepEqual(order, []int{1, 0}) {
+		t.Fatalf("plan = %v, want [1 0]", order)
+	}
+	if _, _, _, ok := planner.evaluate([]int{0, 1}); ok {
+		t.Error("evaluate accepted a drop before its pickup")
+	}
+
+	// 4 km and 3 km at 30 km/h plus both handovers
+	want := start.Add(14*time.Minute + pickupHandoverTime + dropServiceTime)
+	if !finish.Equal(want) {
+		t.Errorf("finish = %s, want %s", finish, want)
+	}
+	if schedule[1].legKm != 3 {
+		t.Errorf("drop leg = %.1f km, want 3", schedule[1].legKm)
+	}
+}
+
+func TestRoutePlannerWaitsForWindow(t *testing.T) {
+	start := time.Date(2025, 10, 26, 10, 0, 0, 0, time.UTC)
+	opens := start.Add(30 * time.Minute)
+	closes := start.Add(time.Hour)
+
+	planner := &routePlanner{
+		stops: []plannedStop{
+			{delivery: &entities.Delivery{WindowStart: &opens, WindowEnd: &closes}, kind: "drop", point: 1, pickup: -1},
+		},
+		matrix:   lineMatrix(2),
+		speedKmh: 60,
+		start:    start,
+	}
+
+	_, schedule, finish := planner.plan()
+	if got, want := schedule[0].wait, 28*time.Minute; got != want {
+		t.Errorf("wait = %s, want %s", got, want)
+	}
+	if want := opens.Add(dropServiceTime); !finish.Equal(want) {
+		t.Errorf("finish = %s, want %s", finish, want)
+	}
+}
+
+func TestReverseSegment(t *testing.T) {
+	order := []int{0, 1, 2, 3, 4}
+	if got, want := reverseSegment(order, 1, 3), []int{0, 3, 2, 1, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reverseSegment = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(order, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("reverseSegment changed its input to %v", order)
+	}
+}
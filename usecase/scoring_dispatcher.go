@@ -0,0 +1,528 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/utils"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+)
+
+// DispatchWeights sets how much each factor counts towards a candidate's score
+type DispatchWeights struct {
+	Distance float64
+	Load     float64
+	Rating   float64
+	Vehicle  float64
+}
+
+var DefaultDispatchWeights = DispatchWeights{
+	Distance: 0.5,
+	Load:     0.2,
+	Rating:   0.2,
+	Vehicle:  0.1,
+}
+
+type DispatchConfig struct {
+	Strategy string
+	// Partners further than this from the pickup are not considered (default 8)
+	MaxRadiusKm float64
+	// Partners with this many open orders are not given more
+	MaxActiveOrders int
+	// Partners whose last location is older than this are treated as offline
+	LocationMaxAge time.Duration
+	// How long before a scheduled window opens the order may be dispatched
+	ScheduleLeadTime time.Duration
+	Weights          DispatchWeights
+	// Offer strategy: how many partners see an order at once, for how long,
+	// and how long a partner who let it pass is skipped for that order
+	OfferFanout     int
+	OfferTTL        time.Duration
+	ReofferCooldown time.Duration
+	// Orders of a zone only go to partners based in it or currently within
+	// this distance of it
+	ZoneNearKm float64
+}
+
+const (
+	// Used for partners without any ratings yet
+	neutralRating = 4.0
+	// Nearest partners looked at per delivery
+	dispatchCandidateLimit  = 50
+	defaultDispatchRadiusKm = 8
+)
+
+// dispatchPass is what one pass knows about the partners it has looked at,
+// so orders dispatched earlier in the pass count against them
+type dispatchPass struct {
+	load     map[string]int                 // open orders plus pending offers
+	batches  map[string][]entities.Delivery // orders taking up room in the vehicle
+	excluded map[string]bool                // partners not to give the order to
+}
+
+func newDispatchPass() *dispatchPass {
+	return &dispatchPass{
+		load:     map[string]int{},
+		batches:  map[string][]entities.Delivery{},
+		excluded: map[string]bool{},
+	}
+}
+
+// ScoringDispatcher gives every pending order to the highest scoring partner
+type ScoringDispatcher struct {
+	deliveryRepo repositories.DeliveryRepository
+	partnerRepo  repositories.DeliveryPartnerRepository
+	offerRepo    repositories.OfferRepository
+	zoneRepo     repositories.ZoneRepository
+	assigner     OrderAssigner
+	config       DispatchConfig
+}
+
+func NewScoringDispatcher(
+	deliveryRepo repositories.DeliveryRepository,
+	partnerRepo repositories.DeliveryPartnerRepository,
+	offerRepo repositories.OfferRepository,
+	zoneRepo repositories.ZoneRepository,
+	assigner OrderAssigner,
+	config DispatchConfig,
+) *ScoringDispatcher {
+	if !validDispatchStrategy(config.Strategy) {
+		log.Printf("⚠️  Unknown dispatch strategy %q; using %q", config.Strategy, DispatchStrategyOffer)
+		config.Strategy = DispatchStrategyOffer
+	}
+	if config.OfferFanout < 1 {
+		config.OfferFanout = 1
+	}
+	if config.MaxRadiusKm <= 0 {
+		config.MaxRadiusKm = defaultDispatchRadiusKm
+	}
+	return &ScoringDispatcher{
+		deliveryRepo: deliveryRepo,
+		partnerRepo:  partnerRepo,
+		offerRepo:    offerRepo,
+		zoneRepo:     zoneRepo,
+		assigner:     assigner,
+		config:       config,
+	}
+}
+
+func (d *ScoringDispatcher) Name() string {
+	return "scoring"
+}
+
+func (d *ScoringDispatcher) Dispatch() (*entities.DispatchRunResponse, error) {
+	response := &entities.DispatchRunResponse{
+		Success:   true,
+		Strategy:  d.config.Strategy,
+		Decisions: []entities.DispatchDecision{},
+	}
+	if d.config.Strategy == DispatchStrategyOff {
+		return response, nil
+	}
+
+	now := time.Now()
+
+	// Orders whose offers ran out become available for the next candidates below
+	if _, err := d.offerRepo.ExpireDue(now); err != nil {
+		return &entities.DispatchRunResponse{
+			Success: false,
+			Error:   "Failed to expire offers",
+		}, err
+	}
+
+	pending, err := d.deliveryRepo.GetPendingOrders(now.Add(d.config.ScheduleLeadTime))
+	if err != nil {
+		return &entities.DispatchRunResponse{
+			Success: false,
+			Error:   "Failed to load pending orders",
+		}, err
+	}
+
+	deliveries, err := d.undispatched(pending)
+	if err != nil {
+		return &entities.DispatchRunResponse{
+			Success: false,
+			Error:   "Failed to load open offers",
+		}, err
+	}
+	response.Considered = len(deliveries)
+	if len(deliveries) == 0 {
+		return response, nil
+	}
+
+	// Most urgent orders get first pick of the partners
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return dueFrom(&deliveries[i]).Before(dueFrom(&deliveries[j]))
+	})
+
+	// Partner state is shared across the pass so assignments and offers made in it count too
+	pass := newDispatchPass()
+	for i := range deliveries {
+		decision := d.dispatchOne(&deliveries[i], pass, now)
+		if decision.Action == "assigned" || decision.Action == "offered" {
+			response.Dispatched++
+		}
+		response.Decisions = append(response.Decisions, decision)
+	}
+
+	return response, nil
+}
+
+// Redispatch looks for the next candidate for one delivery right away, e.g.
+// after its last open offer was rejected
+func (d *ScoringDispatcher) Redispatch(deliveryID string, excludePartnerIDs []string) (*entities.DispatchDecision, error) {
+	decision := &entities.DispatchDecision{
+		DeliveryID: deliveryID,
+		Action:     "skipped",
+	}
+	if d.config.Strategy == DispatchStrategyOff {
+		decision.Reason = "dispatch is off"
+		return decision, nil
+	}
+
+	delivery, err := d.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	decision.OrderID = delivery.OrderID
+
+	if delivery.Status != "pending" {
+		decision.Reason = "order is no longer pending"
+		return decision, nil
+	}
+
+	open, err := d.undispatched([]entities.Delivery{*delivery})
+	if err != nil {
+		return nil, err
+	}
+	if len(open) == 0 {
+		decision.Reason = "order is already offered"
+		return decision, nil
+	}
+
+	pass := newDispatchPass()
+	for _, id := range excludePartnerIDs {
+		pass.excluded[id] = true
+	}
+	result := d.dispatchOne(delivery, pass, time.Now())
+	return &result, nil
+}
+
+// undispatched drops the deliveries that already have a partner or an open offer
+func (d *ScoringDispatcher) undispatched(pending []entities.Delivery) ([]entities.Delivery, error) {
+	ids := make([]string, 0, len(pending))
+	for _, p := range pending {
+		ids = append(ids, p.DeliveryID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	offered, err := d.offerRepo.GetDeliveriesWithPendingOffers(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]entities.Delivery, 0, len(pending))
+	for _, p := range pending {
+		if p.PartnerID == "" && !offered[p.DeliveryID] {
+			deliveries = append(deliveries, p)
+		}
+	}
+	return deliveries, nil
+}
+
+func (d *ScoringDispatcher) dispatchOne(delivery *entities.Delivery, pass *dispatchPass, now time.Time) entities.DispatchDecision {
+	decision := entities.DispatchDecision{
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+	}
+
+	partners, err := d.nearbyPartners(delivery, pass, now)
+	if err != nil {
+		decision.Action = "failed"
+		decision.Reason = err.Error()
+		return decision
+	}
+
+	// Partners who recently passed on this order are not asked again
+	recent, err := d.offerRepo.GetOfferedPartners(delivery.DeliveryID, now.Add(-d.config.ReofferCooldown))
+	if err != nil {
+		decision.Action = "failed"
+		decision.Reason = err.Error()
+		return decision
+	}
+	skip := make(map[string]bool, len(recent)+len(pass.excluded))
+	for _, id := range recent {
+		skip[id] = true
+	}
+	for id := range pass.excluded {
+		skip[id] = true
+	}
+
+	// Best first, skipping partners whose vehicle has no room for the order
+	// on top of what they already carry
+	want := d.config.OfferFanout
+	if d.config.Strategy == DispatchStrategyAssign {
+		want = 1
+	}
+	candidates := make([]entities.DispatchCandidate, 0, want)
+	for _, c := range d.rank(delivery, partners, pass.load) {
+		if len(candidates) == want {
+			break
+		}
+		if skip[c.PartnerID] {
+			continue
+		}
+		if capacityShortfall(c.VehicleType, delivery, pass.batches[c.PartnerID]) == "" {
+			candidates = append(candidates, c)
+		}
+	}
+
+	if len(candidates) == 0 {
+		decision.Action = "skipped"
+		decision.Reason = "no eligible partner"
+		return decision
+	}
+
+	best := candidates[0]
+	decision.PartnerID = best.PartnerID
+	decision.Score = best.Score
+
+	if d.config.Strategy == DispatchStrategyAssign {
+		var partner *entities.DeliveryPartner
+		for i := range partners {
+			if partners[i].PartnerID == best.PartnerID {
+				partner = &partners[i]
+			}
+		}
+		if err := d.assigner.AssignOrder(delivery, partner); err != nil {
+			decision.Action = "failed"
+			decision.Reason = err.Error()
+			return decision
+		}
+		// Count the new order so the same partner is not overloaded in this pass
+		pass.load[best.PartnerID]++
+		pass.batches[best.PartnerID] = append(pass.batches[best.PartnerID], *delivery)
+		decision.Action = "assigned"
+		return decision
+	}
+
+	offers := make([]entities.Offer, 0, len(candidates))
+	for _, c := range candidates {
+		offers = append(offers, entities.Offer{
+			DeliveryID: delivery.DeliveryID,
+			OrderID:    delivery.OrderID,
+			PartnerID:  c.PartnerID,
+			Score:      c.Score,
+			Status:     "pending",
+			ExpiresAt:  now.Add(d.config.OfferTTL),
+		})
+	}
+
+	if err := d.offerRepo.CreateMany(offers); err != nil {
+		decision.Action = "failed"
+		decision.Reason = err.Error()
+		return decision
+	}
+
+	// Each offer may be accepted, so count it against the partner for the rest of the pass
+	for _, c := range candidates {
+		pass.load[c.PartnerID]++
+	}
+
+	decision.Action = "offered"
+	if len(offers) > 1 {
+		decision.Reason = fmt.Sprintf("offered to %d partners", len(offers))
+	}
+	return decision
+}
+
+func (d *ScoringDispatcher) RankCandidates(deliveryID string) (*entities.GetDispatchCandidatesResponse, error) {
+	delivery, err := d.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.GetDispatchCandidatesResponse{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	pass := newDispatchPass()
+	partners, err := d.nearbyPartners(delivery, pass, time.Now())
+	if err != nil {
+		return &entities.GetDispatchCandidatesResponse{
+			Success: false,
+			Error:   "Failed to load partners",
+		}, err
+	}
+
+	return &entities.GetDispatchCandidatesResponse{
+		Success:    true,
+		DeliveryID: deliveryID,
+		Candidates: d.rank(delivery, partners, pass.load),
+	}, nil
+}
+
+// nearbyPartners returns the available partners within the dispatch radius of
+// the pickup whose location is recent, and loads the orders and offers of any
+// partner the pass hasn't seen yet
+func (d *ScoringDispatcher) nearbyPartners(delivery *entities.Delivery, pass *dispatchPass, now time.Time) ([]entities.DeliveryPartner, error) {
+	pickup := entities.NewGeoPoint(delivery.PickupLatitude, delivery.PickupLongitude)
+	locatedSince := now.Add(-d.config.LocationMaxAge)
+	nearby, err := d.partnerRepo.FindAvailablePartnersNear(*pickup, d.config.MaxRadiusKm, locatedSince, dispatchCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := d.deliveryZones(delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	partners := make([]entities.DeliveryPartner, 0, len(nearby))
+	var uncounted []string
+	for _, p := range nearby {
+		if len(zones) > 0 && !servesZones(&p, zones, d.config.ZoneNearKm) {
+			continue
+		}
+		partners = append(partners, p)
+		if _, ok := pass.load[p.PartnerID]; !ok {
+			uncounted = append(uncounted, p.PartnerID)
+		}
+	}
+
+	// Offers waiting for an answer count too, or one partner could be offered
+	// every order in a pass
+	if len(uncounted) > 0 {
+		active, err := d.deliveryRepo.GetActiveOrdersByPartners(uncounted)
+		if err != nil {
+			return nil, err
+		}
+		offered, err := d.offerRepo.CountPendingByPartners(uncounted)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range uncounted {
+			pass.load[id] = len(active[id]) + offered[id]
+			pass.batches[id] = heldOrders(active[id])
+		}
+	}
+
+	return partners, nil
+}
+
+// deliveryZones returns the zones served by the order's warehouse, or the
+// zones around the pickup if the warehouse has none. Orders outside any zone
+// can go to anyone.
+func (d *ScoringDispatcher) deliveryZones(delivery *entities.Delivery) ([]entities.Zone, error) {
+	if delivery.WarehouseID != "" {
+		zones, err := d.zoneRepo.GetByWarehouse(delivery.WarehouseID)
+		if err != nil || len(zones) > 0 {
+			return zones, err
+		}
+	}
+	return d.zoneRepo.FindContaining(*entities.NewGeoPoint(delivery.PickupLatitude, delivery.PickupLongitude))
+}
+
+// rank returns the eligible partners for the delivery, best score first
+func (d *ScoringDispatcher) rank(delivery *entities.Delivery, partners []entities.DeliveryPartner, load map[string]int) []entities.DispatchCandidate {
+	_, _, size := deliveryLoad(delivery)
+	candidates := make([]entities.DispatchCandidate, 0, len(partners))
+	for _, p := range partners {
+		if !takesOffers(&p) {
+			continue
+		}
+
+		active := load[p.PartnerID]
+		if d.config.MaxActiveOrders > 0 && active >= d.config.MaxActiveOrders {
+			continue
+		}
+
+		if !vehicleTakes(p.VehicleType, size) {
+			continue
+		}
+
+		distance := utils.HaversineKm(p.CurrentLatitude, p.CurrentLongitude, delivery.PickupLatitude, delivery.PickupLongitude)
+		if distance > d.config.MaxRadiusKm {
+			continue
+		}
+
+		candidates = append(candidates, entities.DispatchCandidate{
+			PartnerID:    p.PartnerID,
+			Name:         p.Name,
+			VehicleType:  p.VehicleType,
+			DistanceKm:   math.Round(distance*100) / 100,
+			ActiveOrders: active,
+			Rating:       p.Rating,
+			Score:        d.score(delivery, &p, distance, active),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].DistanceKm < candidates[j].DistanceKm
+	})
+	return candidates
+}
+
+// score combines the weighted factors, each normalised to 0-1
+func (d *ScoringDispatcher) score(delivery *entities.Delivery, partner *entities.DeliveryPartner, distanceKm float64, activeOrders int) float64 {
+	w := d.config.Weights
+
+	distanceScore := math.Max(0, 1-distanceKm/d.config.MaxRadiusKm)
+
+	loadScore := 1.0
+	if d.config.MaxActiveOrders > 0 {
+		loadScore = 1 - float64(activeOrders)/float64(d.config.MaxActiveOrders)
+	}
+
+	rating := partner.Rating
+	if rating == 0 {
+		rating = neutralRating
+	}
+	ratingScore := rating / 5
+
+	vehicleScore := vehicleFit(partner.VehicleType, delivery)
+
+	total := w.Distance + w.Load + w.Rating + w.Vehicle
+	if total == 0 {
+		return 0
+	}
+
+	score := (w.Distance*distanceScore + w.Load*loadScore + w.Rating*ratingScore + w.Vehicle*vehicleScore) / total
+	return math.Round(score*1000) / 1000
+}
+
+// vehicleFit prefers two-wheelers for small and medium orders and the
+// smallest vehicle that takes larger ones
+func vehicleFit(vehicleType string, delivery *entities.Delivery) float64 {
+	_, _, size := deliveryLoad(delivery)
+	switch size {
+	case "small", "medium":
+		switch vehicleType {
+		case "bike":
+			return 1
+		case "scooter":
+			return 0.9
+		case "car":
+			return 0.6
+		}
+	case "large":
+		switch vehicleType {
+		case "scooter":
+			return 1
+		case "car":
+			return 0.9
+		}
+	case "xl":
+		if vehicleType == "car" {
+			return 1
+		}
+	}
+	return 0.7
+}
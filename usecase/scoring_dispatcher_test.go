@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	testPickupLat = 12.9716
+	testPickupLng = 77.5946
+	kmPerDegLat   = 111.2
+)
+
+// testPartner is an available partner kmNorth of the test pickup
+func testPartner(id, vehicleType string, kmNorth float64) entities.DeliveryPartner {
+	return entities.DeliveryPartner{
+		PartnerID:        id,
+		Name:             id,
+		IsAvailable:      true,
+		IsVerified:       true,
+		Presence:         entities.PresenceOnline,
+		Rating:           4.5,
+		VehicleType:      vehicleType,
+		CurrentLatitude:  testPickupLat + kmNorth/kmPerDegLat,
+		CurrentLongitude: testPickupLng,
+		LastLocationAt:   time.Now(),
+	}
+}
+
+// testDelivery is a pending order at the test pickup
+func testDelivery(id string, weightKg float64) entities.Delivery {
+	return entities.Delivery{
+		DeliveryID:      id,
+		OrderID:         "order-" + id,
+		Status:          "pending",
+		PickupLatitude:  testPickupLat,
+		PickupLongitude: testPickupLng,
+		WeightKg:        weightKg,
+		VolumeLitres:    5,
+		SizeClass:       sizeClassFor(weightKg, 5),
+		CreatedAt:       time.Now().Add(-time.Minute),
+	}
+}
+
+// heldBy is an order the partner is already carrying
+func heldBy(id, partnerID string, weightKg float64) entities.Delivery {
+	d := testDelivery(id, weightKg)
+	d.PartnerID = partnerID
+	d.Status = "assigned"
+	return d
+}
+
+type dispatchFixture struct {
+	dispatcher *ScoringDispatcher
+	deliveries *memoryDeliveryRepo
+	offers     *memoryOfferRepo
+}
+
+func newDispatchFixture(strategy string, partners []entities.DeliveryPartner, deliveries ...entities.Delivery) *dispatchFixture {
+	deliveryRepo := newMemoryDeliveryRepo(deliveries...)
+	offerRepo := &memoryOfferRepo{}
+	dispatcher := NewScoringDispatcher(
+		deliveryRepo,
+		&memoryPartnerRepo{partners: partners},
+		offerRepo,
+		&memoryZoneRepo{},
+		&memoryAssigner{deliveryRepo: deliveryRepo},
+		DispatchConfig{
+			Strategy:        strategy,
+			MaxRadiusKm:     8,
+			MaxActiveOrders: 3,
+			LocationMaxAge:  10 * time.Minute,
+			Weights:         DefaultDispatchWeights,
+			OfferFanout:     1,
+			OfferTTL:        time.Minute,
+			ReofferCooldown: 10 * time.Minute,
+			ZoneNearKm:      2,
+		},
+	)
+	return &dispatchFixture{dispatcher: dispatcher, deliveries: deliveryRepo, offers: offerRepo}
+}
+
+func candidateIDs(candidates []entities.DispatchCandidate) []string {
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.PartnerID)
+	}
+	return ids
+}
+
+func TestRankCandidates(t *testing.T) {
+	onBreak := testPartner("on-break", "bike", 0.2)
+	onBreak.Presence = entities.PresenceOnBreak
+	stale := testPartner("stale", "bike", 0.3)
+	stale.Presence = entities.PresenceStale
+	lost := testPartner("lost", "bike", 0.1)
+	lost.LastLocationAt = time.Now().Add(-time.Hour)
+
+	partners := []entities.DeliveryPartner{
+		testPartner("far", "bike", 5),
+		testPartner("near", "bike", 1),
+		testPartner("busy", "bike", 0.5),
+		testPartner("full", "bike", 0.4),
+		testPartner("out-of-range", "bike", 20),
+		onBreak,
+		stale,
+		lost,
+	}
+	f := newDispatchFixture(DispatchStrategyOffer, partners,
+		testDelivery("d1", 1),
+		heldBy("b1", "busy", 1), heldBy("b2", "busy", 1),
+		heldBy("f1", "full", 1), heldBy("f2", "full", 1), heldBy("f3", "full", 1),
+	)
+
+	response, err := f.dispatcher.RankCandidates("d1")
+	if err != nil {
+		t.Fatalf("RankCandidates: %v", err)
+	}
+
+	// Load outweighs the half kilometre busy is closer by
+	want := []string{"near", "busy", "far"}
+	if got := candidateIDs(response.Candidates); !reflect.DeepEqual(got, want) {
+		t.Fatalf("candidates = %v, want %v", got, want)
+	}
+	if got := response.Candidates[1].ActiveOrders; got != 2 {
+		t.Errorf("busy ActiveOrders = %d, want 2", got)
+	}
+	for i := 1; i < len(response.Candidates); i++ {
+		if response.Candidates[i].Score > response.Candidates[i-1].Score {
+			t.Errorf("candidate %d scores higher than candidate %d", i, i-1)
+		}
+	}
+}
+
+func TestRankCandidatesLeavesOutVehiclesTooSmall(t *testing.T) {
+	partners := []entities.DeliveryPartner{
+		testPartner("bike", "bike", 0.5),
+		testPartner("car", "car", 3),
+	}
+	f := newDispatchFixture(DispatchStrategyOffer, partners, testDelivery("d1", 20))
+
+	response, err := f.dispatcher.RankCandidates("d1")
+	if err != nil {
+		t.Fatalf("RankCandidates: %v", err)
+	}
+	if got, want := candidateIDs(response.Candidates), []string{"car"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates = %v, want %v", got, want)
+	}
+}
+
+func TestDispatchStrategies(t *testing.T) {
+	tests := []struct {
+		strategy   string
+		dispatched int
+		status     string
+		partnerID  string
+		offeredTo  []string
+	}{
+		{DispatchStrategyOff, 0, "pending", "", nil},
+		{DispatchStrategyOffer, 1, "pending", "", []string{"near"}},
+		{DispatchStrategyAssign, 1, "assigned", "near", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			partners := []entities.DeliveryPartner{
+				testPartner("near", "bike", 1),
+				testPartner("far", "bike", 5),
+			}
+			f := newDispatchFixture(tt.strategy, partners, testDelivery("d1", 1))
+
+			response, err := f.dispatcher.Dispatch()
+			if err != nil {
+				t.Fatalf("Dispatch: %v", err)
+			}
+			if response.Dispatched != tt.dispatched {
+				t.Errorf("Dispatched = %d, want %d", response.Dispatched, tt.dispatched)
+			}
+
+			d := f.deliveries.deliveries["d1"]
+			if d.Status != tt.status || d.PartnerID != tt.partnerID {
+				t.Errorf("delivery is %s with %q, want %s with %q", d.Status, d.PartnerID, tt.status, tt.partnerID)
+			}
+			if got := f.offers.pendingFor("d1"); !reflect.DeepEqual(got, tt.offeredTo) {
+				t.Errorf("offered to %v, want %v", got, tt.offeredTo)
+			}
+		})
+	}
+}
+
+func TestUnknownDispatchStrategyFallsBackToOffer(t *testing.T) {
+	f := newDispatchFixture("auto", []entities.DeliveryPartner{testPartner("near", "bike", 1)}, testDelivery("d1", 1))
+
+	if _, err := f.dispatcher.Dispatch(); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if got := f.offers.pendingFor("d1"); !reflect.DeepEqual(got, []string{"near"}) {
+		t.Errorf("offered to %v, want [near]", got)
+	}
+}
+
+func TestDispatchAlreadyOfferedOrder(t *testing.T) {
+	partners := []entities.DeliveryPartner{
+		testPartner("near", "bike", 1),
+		testPartner("far", "bike", 5),
+	}
+	f := newDispatchFixture(DispatchStrategyOffer, partners, testDelivery("d1", 1))
+
+	for i := 0; i < 2; i++ {
+		if _, err := f.dispatcher.Dispatch(); err != nil {
+			t.Fatalf("Dispatch: %v", err)
+		}
+	}
+
+	// The open offer stands; nobody else is asked until it runs out
+	if got := f.offers.pendingFor("d1"); !reflect.DeepEqual(got, []string{"near"}) {
+		t.Errorf("offered to %v, want [near]", got)
+	}
+}
+
+func TestRedispatch(t *testing.T) {
+	partners := []entities.DeliveryPartner{
+		testPartner("near", "bike", 1),
+		testPartner("far", "bike", 5),
+	}
+
+	t.Run("leaves out excluded partners", func(t *testing.T) {
+		f := newDispatchFixture(DispatchStrategyOffer, partners, testDelivery("d1", 1))
+
+		decision, err := f.dispatcher.Redispatch("d1", []string{"near"})
+		if err != nil {
+			t.Fatalf("Redispatch: %v", err)
+		}
+		if decision.Action != "offered" || decision.PartnerID != "far" {
+			t.Errorf("decision = %s to %q, want offered to far", decision.Action, decision.PartnerID)
+		}
+	})
+
+	t.Run("skips when everyone is excluded", func(t *testing.T) {
+		f := newDispatchFixture(DispatchStrategyAssign, partners, testDelivery("d1", 1))
+
+		decision, err := f.dispatcher.Redispatch("d1", []string{"near", "far"})
+		if err != nil {
+			t.Fatalf("Redispatch: %v", err)
+		}
+		if decision.Action != "skipped" {
+			t.Errorf("Action = %s, want skipped", decision.Action)
+		}
+		if d := f.deliveries.deliveries["d1"]; d.Status != "pending" {
+			t.Errorf("delivery is %s, want pending", d.Status)
+		}
+	})
+
+	t.Run("leaves orders that are no longer pending", func(t *testing.T) {
+		f := newDispatchFixture(DispatchStrategyAssign, partners, heldBy("d1", "near", 1))
+
+		decision, err := f.dispatcher.Redispatch("d1", nil)
+		if err != nil {
+			t.Fatalf("Redispatch: %v", err)
+		}
+		if decision.Action != "skipped" || f.deliveries.deliveries["d1"].PartnerID != "near" {
+			t.Errorf("decision = %s to %q, want the order left with near", decision.Action, decision.PartnerID)
+		}
+	})
+
+	t.Run("does nothing when dispatch is off", func(t *testing.T) {
+		f := newDispatchFixture(DispatchStrategyOff, partners, testDelivery("d1", 1))
+
+		decision, err := f.dispatcher.Redispatch("d1", nil)
+		if err != nil {
+			t.Fatalf("Redispatch: %v", err)
+		}
+		if decision.Action != "skipped" || len(f.offers.offers) != 0 {
+			t.Errorf("decision = %s with %d offers, want skipped with none", decision.Action, len(f.offers.offers))
+		}
+	})
+}
+
+func TestDispatchRespectsCapacity(t *testing.T) {
+	t.Run("orders already carried", func(t *testing.T) {
+		partners := []entities.DeliveryPartner{
+			testPartner("bike", "bike", 0.5),
+			testPartner("scooter", "scooter", 4),
+		}
+		// 8 kg on board plus 6 kg is over a bike's 12 kg
+		f := newDispatchFixture(DispatchStrategyAssign, partners,
+			testDelivery("d1", 6),
+			heldBy("held", "bike", 8),
+		)
+
+		if _, err := f.dispatcher.Dispatch(); err != nil {
+			t.Fatalf("Dispatch: %v", err)
+		}
+		if got := f.deliveries.deliveries["d1"].PartnerID; got != "scooter" {
+			t.Errorf("assigned to %q, want scooter", got)
+		}
+	})
+
+	t.Run("orders assigned earlier in the pass", func(t *testing.T) {
+		f := newDispatchFixture(DispatchStrategyAssign,
+			[]entities.DeliveryPartner{testPartner("bike", "bike", 0.5)},
+			testDelivery("d1", 7),
+			testDelivery("d2", 7),
+		)
+
+		response, err := f.dispatcher.Dispatch()
+		if err != nil {
+			t.Fatalf("Dispatch: %v", err)
+		}
+		if response.Dispatched != 1 {
+			t.Errorf("Dispatched = %d, want 1", response.Dispatched)
+		}
+		assigned := 0
+		for _, d := range f.deliveries.deliveries {
+			if d.PartnerID == "bike" {
+				assigned++
+			}
+		}
+		if assigned != 1 {
+			t.Errorf("bike was given %d orders, want 1", assigned)
+		}
+	})
+
+	t.Run("pending offers count towards the order limit", func(t *testing.T) {
+		partners := []entities.DeliveryPartner{
+			testPartner("near", "bike", 1),
+			testPartner("far", "bike", 5),
+		}
+		f := newDispatchFixture(DispatchStrategyOffer, partners, testDelivery("d1", 1))
+		for _, id := range []string{"o1", "o2", "o3"} {
+			f.offers.offers = append(f.offers.offers, entities.Offer{
+				DeliveryID: id,
+				PartnerID:  "near",
+				Status:     "pending",
+				ExpiresAt:  time.Now().Add(time.Minute),
+			})
+		}
+
+		if _, err := f.dispatcher.Dispatch(); err != nil {
+			t.Fatalf("Dispatch: %v", err)
+		}
+		if got := f.offers.pendingFor("d1"); !reflect.DeepEqual(got, []string{"far"}) {
+			t.Errorf("offered to %v, want [far]", got)
+		}
+	})
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/domain/services"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// slaCheckWorkers bounds the deliveries checked at once, and so the
+// concurrent calls to the route provider
+const slaCheckWorkers = 8
+
+type SLAUseCase struct {
+	deliveryRepo repositories.DeliveryRepository
+	partnerRepo  repositories.DeliveryPartnerRepository
+	routes       services.RouteProvider
+	defaultSLA   time.Duration
+	atRiskBuffer time.Duration
+}
+
+func NewSLAUseCase(
+	deliveryRepo repositories.DeliveryRepository,
+	partnerRepo repositories.DeliveryPartnerRepository,
+	routes services.RouteProvider,
+	defaultSLA time.Duration,
+	atRiskBuffer time.Duration,
+) *SLAUseCase {
+	return &SLAUseCase{
+		deliveryRepo: deliveryRepo,
+		partnerRepo:  partnerRepo,
+		routes:       routes,
+		defaultSLA:   defaultSLA,
+		atRiskBuffer: atRiskBuffer,
+	}
+}
+
+// Run recomputes ETAs and SLA status for in-progress deliveries until the context is cancelled
+func (uc *SLAUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.CheckDeliveries(); err != nil {
+				log.Printf("❌ SLA check failed: %v", err)
+			}
+		}
+	}
+}
+
+func (uc *SLAUseCase) CheckDeliveries() error {
+	deliveries, err := uc.deliveryRepo.GetInProgressDeliveries()
+	if err != nil {
+		return err
+	}
+
+	var partnerIDs []string
+	seen := make(map[string]bool)
+	for _, d := range deliveries {
+		if d.PartnerID != "" && !seen[d.PartnerID] {
+			seen[d.PartnerID] = true
+			partnerIDs = append(partnerIDs, d.PartnerID)
+		}
+	}
+
+	// Without partners the ETAs are projected from the warehouse, which is
+	// still better than skipping the pass
+	partners := map[string]*entities.DeliveryPartner{}
+	if len(partnerIDs) > 0 {
+		found, err := uc.partnerRepo.FindByIDs(partnerIDs)
+		if err != nil {
+			log.Printf("❌ Failed to load partners for the SLA check: %v", err)
+		} else {
+			partners = found
+		}
+	}
+
+	now := time.Now()
+
+	// Each delivery may need a route provider call, so they are checked in
+	// parallel, a bounded number at a time
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, slaCheckWorkers)
+	for i := range deliveries {
+		d := &deliveries[i]
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			uc.check(d, partners[d.PartnerID], now)
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (uc *SLAUseCase) check(delivery *entities.Delivery, partner *entities.DeliveryPartner, now time.Time) {
+	update := uc.evaluate(delivery, partner, now)
+	if update.SLAStatus == "breached" && delivery.SLAStatus != "breached" {
+		log.Printf("⚠️  Delivery %s (order %s) breached its SLA", delivery.DeliveryID, delivery.OrderID)
+	}
+
+	if err := uc.deliveryRepo.UpdateSLA(delivery.DeliveryID, update); err != nil {
+		log.Printf("❌ Failed to update SLA for delivery %s: %v", delivery.DeliveryID, err)
+	}
+}
+
+func (uc *SLAUseCase) evaluate(delivery *entities.Delivery, partner *entities.DeliveryPartner, now time.Time) *entities.DeliverySLAUpdate {
+	update := &entities.DeliverySLAUpdate{}
+
+	// Scheduled deliveries are due by the end of their window
+	deadline := delivery.SLADeadline
+	if deadline == nil {
+		d := slaDeadline(delivery.CreatedAt, delivery.WindowEnd, uc.defaultSLA)
+		deadline = &d
+		update.SLADeadline = deadline
+	}
+
+	// Orders that came in without a computed route get one on their first check
+	if delivery.RouteSource == "" {
+		if route, err := deliveryRoute(uc.routes, delivery); err != nil {
+			log.Printf("⚠️  Failed to route delivery %s: %v", delivery.DeliveryID, err)
+		} else {
+			applyRoute(delivery, route)
+			update.Route = route
+		}
+	}
+
+	// Nobody is moving an unassigned order, so only project from the warehouse once assigned
+	if delivery.PartnerID != "" {
+		eta, remainingKm := estimateArrival(delivery, partner, uc.routes, now)
+		update.ETA = &eta
+		update.RemainingDistance = remainingKm
+	}
+
+	switch {
+	case now.After(*deadline):
+		update.SLAStatus = "breached"
+		if delivery.SLABreachedAt == nil {
+			update.SLABreachedAt = &now
+		}
+	case update.ETA != nil && update.ETA.After(deadline.Add(-uc.atRiskBuffer)):
+		update.SLAStatus = "at_risk"
+	case update.ETA == nil && now.After(deadline.Add(-uc.atRiskBuffer)):
+		update.SLAStatus = "at_risk"
+	default:
+		update.SLAStatus = "on_track"
+	}
+
+	return update
+}
+
+func (uc *SLAUseCase) GetSLAAlerts(req *entities.GetSLAAlertsRequest) (*entities.GetSLAAlertsResponse, error) {
+	statuses := []string{"at_risk", "breached"}
+	if req.Status != "" {
+		statuses = strings.Split(req.Status, ",")
+	}
+
+	deliveries, err := uc.deliveryRepo.GetDeliveriesBySLAStatus(statuses, req.WarehouseID)
+	if err != nil {
+		return &entities.GetSLAAlertsResponse{
+			Success: false,
+		}, err
+	}
+
+	now := time.Now()
+	items := make([]entities.SLAAlertItem, 0, len(deliveries))
+	for _, d := range deliveries {
+		items = append(items, entities.SLAAlertItem{
+			ID:          d.DeliveryID,
+			OrderID:     d.OrderID,
+			PartnerID:   d.PartnerID,
+			WarehouseID: d.WarehouseID,
+			Status:      d.Status,
+			SLAStatus:   d.SLAStatus,
+			SLADeadline: d.SLADeadline,
+			ETA:         d.ETA,
+			MinutesLate: minutesLate(&d, now),
+		})
+	}
+
+	return &entities.GetSLAAlertsResponse{
+		Success:    true,
+		Deliveries: items,
+		Count:      len(items),
+	}, nil
+}
+
+// minutesLate is how far past the deadline the order is, or is projected to be
+func minutesLate(delivery *entities.Delivery, now time.Time) int {
+	if delivery.SLADeadline == nil {
+		return 0
+	}
+
+	reference := now
+	if delivery.ETA != nil && delivery.ETA.After(reference) {
+		reference = *delivery.ETA
+	}
+
+	late := reference.Sub(*delivery.SLADeadline)
+	if late < 0 {
+		return 0
+	}
+	return int(late.Minutes())
+}
+
+// slaDeadline is when an order is due: the end of its window if scheduled,
+// otherwise the SLA after it came in
+func slaDeadline(createdAt time.Time, windowEnd *time.Time, sla time.Duration) time.Time {
+	if windowEnd != nil {
+		return *windowEnd
+	}
+	return createdAt.Add(sla)
+}
+
+// finalSLAStatus is recorded when a delivery is completed. Orders created
+// before deadlines were set at creation are held to the default SLA.
+func finalSLAStatus(delivery *entities.Delivery, deliveredAt time.Time, sla time.Duration) string {
+	deadline := slaDeadline(delivery.CreatedAt, delivery.WindowEnd, sla)
+	if delivery.SLADeadline != nil {
+		deadline = *delivery.SLADeadline
+	}
+	if deliveredAt.After(deadline) {
+		return "breached"
+	}
+	return "met"
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestFinalSLAStatus(t *testing.T) {
+	deadline := time.Date(2025, 10, 26, 10, 45, 0, 0, time.UTC)
+	sla := 45 * time.Minute
+	createdAt := deadline.Add(-sla)
+	windowEnd := deadline.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		deadline    *time.Time
+		windowEnd   *time.Time
+		deliveredAt time.Time
+		want        string
+	}{
+		{"early", &deadline, nil, deadline.Add(-10 * time.Minute), "met"},
+		{"on the deadline", &deadline, nil, deadline, "met"},
+		{"late", &deadline, nil, deadline.Add(time.Second), "breached"},
+		{"no deadline, within the SLA", nil, nil, deadline, "met"},
+		{"no deadline, past the SLA", nil, nil, deadline.Add(time.Second), "breached"},
+		{"no deadline, within the window", nil, &windowEnd, deadline.Add(time.Minute), "met"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := &entities.Delivery{CreatedAt: createdAt, SLADeadline: tt.deadline, WindowEnd: tt.windowEnd}
+			if got := finalSLAStatus(delivery, tt.deliveredAt, sla); got != tt.want {
+				t.Errorf("finalSLAStatus = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"log"
+	"sync"
+)
+
+// StreamFilter decides what a subscriber sees. It returns the frame to send,
+// possibly trimmed, or nil to skip it. Filters run on the publishing
+// goroutine with the hub locked, so they may keep state without their own
+// lock. The event is shared between subscribers and must not be modified.
+type StreamFilter func(event *entities.StreamEvent) *entities.StreamEvent
+
+// Subscription is one live stream. Events is closed when the hub drops the
+// subscriber for falling too far behind.
+type Subscription struct {
+	events     chan entities.StreamEvent
+	filter     StreamFilter
+	dropped    int    // consecutive frames dropped on a full buffer
+	deliveryID string // set when the stream follows one delivery
+}
+
+func (s *Subscription) Events() <-chan entities.StreamEvent {
+	return s.events
+}
+
+// Done reports whether a delivery stream has nothing more to send after frame
+func (s *Subscription) Done(frame *entities.StreamEvent) bool {
+	return s.deliveryID != "" && frame.DeliveryID == s.deliveryID && frame.Type == "status" &&
+		(frame.Status == "delivered" || frame.Status == "cancelled")
+}
+
+// StreamHub fans live location and status frames out to in-process
+// subscribers. Publishing never blocks: a subscriber whose buffer is full
+// misses frames, and is disconnected after maxDropped in a row so the client
+// reconnects and starts from a fresh snapshot.
+type StreamHub struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	bufferSize  int
+	maxDropped  int
+}
+
+// defaultStreamLimit is the buffer size and the dropped frame limit used
+// when the configured ones are not positive
+const defaultStreamLimit = 64
+
+func NewStreamHub(bufferSize, maxDropped int) *StreamHub {
+	if bufferSize <= 0 {
+		log.Printf("⚠️  Stream buffer size %d is not positive; using %d", bufferSize, defaultStreamLimit)
+		bufferSize = defaultStreamLimit
+	}
+	if maxDropped <= 0 {
+		log.Printf("⚠️  Stream dropped frame limit %d is not positive; using %d", maxDropped, defaultStreamLimit)
+		maxDropped = defaultStreamLimit
+	}
+	return &StreamHub{
+		subscribers: make(map[*Subscription]struct{}),
+		bufferSize:  bufferSize,
+		maxDropped:  maxDropped,
+	}
+}
+
+func (h *StreamHub) Subscribe(filter StreamFilter) *Subscription {
+	sub := &Subscription{
+		events: make(chan entities.StreamEvent, h.bufferSize),
+		filter: filter,
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+func (h *StreamHub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// Publish streams a delivery lifecycle event, so the hub can sit behind the
+// outbox relay with the other EventPublishers
+func (h *StreamHub) Publish(event *entities.DeliveryEvent) error {
+	h.broadcast(&entities.StreamEvent{
+		ID:         event.EventID,
+		Type:       "status",
+		DeliveryID: event.DeliveryID,
+		OrderID:    event.OrderID,
+		PartnerID:  event.PartnerID,
+		Status:     event.Status,
+		At:         event.OccurredAt,
+	})
+	return nil
+}
+
+// PublishLocation streams a partner's new location
+func (h *StreamHub) PublishLocation(ping *entities.LocationPing) {
+	h.broadcast(&entities.StreamEvent{
+		Type:      "location",
+		PartnerID: ping.PartnerID,
+		Location: &entities.StreamLocation{
+			Latitude:   ping.Latitude,
+			Longitude:  ping.Longitude,
+			Speed:      ping.Speed,
+			Heading:    ping.Heading,
+			RecordedAt: ping.RecordedAt,
+		},
+		At: ping.ReceivedAt,
+	})
+}
+
+func (h *StreamHub) broadcast(event *entities.StreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		frame := sub.filter(event)
+		if frame == nil {
+			continue
+		}
+
+		select {
+		case sub.events <- *frame:
+			sub.dropped = 0
+		default:
+			sub.dropped++
+			if sub.dropped >= h.maxDropped {
+				log.Printf("⚠️  Disconnecting stream subscriber after %d dropped frames", sub.dropped)
+				delete(h.subscribers, sub)
+				close(sub.events)
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"errors"
+	"time"
+)
+
+// StreamUseCase authorizes live streams and decides what each one may see
+type StreamUseCase struct {
+	hub             *StreamHub
+	deliveryRepo    repositories.DeliveryRepository
+	trackingUseCase *TrackingUseCase
+}
+
+func NewStreamUseCase(hub *StreamHub, deliveryRepo repositories.DeliveryRepository, trackingUseCase *TrackingUseCase) *StreamUseCase {
+	return &StreamUseCase{
+		hub:             hub,
+		deliveryRepo:    deliveryRepo,
+		trackingUseCase: trackingUseCase,
+	}
+}
+
+// SubscribeTracking opens a customer stream for a tracking token. It returns
+// nil if the token is invalid or expired. The first frame is the current status.
+func (uc *StreamUseCase) SubscribeTracking(token string) (*Subscription, *entities.StreamEvent, error) {
+	delivery, err := uc.trackingUseCase.ResolveToken(token)
+	if err != nil || delivery == nil {
+		return nil, nil, err
+	}
+
+	sub := uc.hub.Subscribe(deliveryStreamFilter(delivery, true))
+	sub.deliveryID = delivery.DeliveryID
+	return sub, statusSnapshot(delivery, true), nil
+}
+
+// SubscribeOps opens an ops stream for one delivery, one partner or, with no
+// filter, the whole fleet. Only delivery streams start with a snapshot. It
+// returns nil if the delivery doesn't exist.
+func (uc *StreamUseCase) SubscribeOps(req *entities.OpsStreamRequest) (*Subscription, *entities.StreamEvent, error) {
+	if req.DeliveryID != "" {
+		delivery, err := uc.deliveryRepo.GetByID(req.DeliveryID)
+		if errors.Is(err, repositories.ErrDeliveryNotFound) {
+			return nil, nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		sub := uc.hub.Subscribe(deliveryStreamFilter(delivery, false))
+		sub.deliveryID = delivery.DeliveryID
+		return sub, statusSnapshot(delivery, false), nil
+	}
+
+	partnerID := req.PartnerID
+	sub := uc.hub.Subscribe(func(event *entities.StreamEvent) *entities.StreamEvent {
+		if partnerID != "" && event.PartnerID != partnerID {
+			return nil
+		}
+		return event
+	})
+	return sub, nil, nil
+}
+
+func (uc *StreamUseCase) Unsubscribe(sub *Subscription) {
+	uc.hub.Unsubscribe(sub)
+}
+
+// deliveryStreamFilter follows one delivery: its status changes and the
+// location of whoever holds it. Customers get the same view as the tracking
+// page: no partner ID, and a rounded location only while the parcel is on
+// its way.
+func deliveryStreamFilter(delivery *entities.Delivery, customer bool) StreamFilter {
+	deliveryID := delivery.DeliveryID
+	partnerID := delivery.PartnerID
+	status := delivery.Status
+
+	return func(event *entities.StreamEvent) *entities.StreamEvent {
+		switch event.Type {
+		case "status":
+			if event.DeliveryID != deliveryID {
+				return nil
+			}
+			status = event.Status
+			partnerID = event.PartnerID
+			if status == "unassigned" {
+				partnerID = ""
+			}
+			if !customer {
+				return event
+			}
+			frame := *event
+			frame.PartnerID = ""
+			return &frame
+
+		case "location":
+			if partnerID == "" || event.PartnerID != partnerID {
+				return nil
+			}
+			frame := *event
+			frame.DeliveryID = deliveryID
+			if !customer {
+				return &frame
+			}
+			if status != "picked_up" && status != "in_transit" {
+				return nil
+			}
+			frame.PartnerID = ""
+			frame.Location = &entities.StreamLocation{
+				Latitude:   approximateCoordinate(event.Location.Latitude),
+				Longitude:  approximateCoordinate(event.Location.Longitude),
+				RecordedAt: event.Location.RecordedAt,
+			}
+			return &frame
+		}
+		return nil
+	}
+}
+
+func statusSnapshot(delivery *entities.Delivery, customer bool) *entities.StreamEvent {
+	frame := &entities.StreamEvent{
+		Type:       "status",
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+		Status:     delivery.Status,
+		At:         time.Now(),
+	}
+	if !customer {
+		frame.PartnerID = delivery.PartnerID
+	}
+	return frame
+}
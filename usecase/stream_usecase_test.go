@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestDeliveryStreamFilter(t *testing.T) {
+	const lat, lng = 12.971634, 77.594562
+	roundedLat, roundedLng := approximateCoordinate(lat), approximateCoordinate(lng)
+
+	status := func(deliveryID, partnerID, s string) *entities.StreamEvent {
+		return &entities.StreamEvent{Type: "status", DeliveryID: deliveryID, PartnerID: partnerID, Status: s}
+	}
+	location := func(partnerID string) *entities.StreamEvent {
+		return &entities.StreamEvent{
+			Type:      "location",
+			PartnerID: partnerID,
+			Location:  &entities.StreamLocation{Latitude: lat, Longitude: lng, RecordedAt: time.Now()},
+		}
+	}
+
+	type step struct {
+		event *entities.StreamEvent
+		want  *entities.StreamEvent // nil when the frame is skipped
+	}
+
+	tests := []struct {
+		name     string
+		status   string
+		customer bool
+		steps    []step
+	}{
+		{
+			name:   "ops see exact locations with the partner",
+			status: "assigned",
+			steps: []step{
+				{location("p1"), &entities.StreamEvent{Type: "location", DeliveryID: "d1", PartnerID: "p1", Location: &entities.StreamLocation{Latitude: lat, Longitude: lng}}},
+				{status("d1", "p1", "picked_up"), &entities.StreamEvent{Type: "status", DeliveryID: "d1", PartnerID: "p1", Status: "picked_up"}},
+			},
+		},
+		{
+			name:     "customers get no location before pickup",
+			status:   "assigned",
+			customer: true,
+			steps: []step{
+				{location("p1"), nil},
+			},
+		},
+		{
+			name:     "customers get a rounded location without the partner while on the way",
+			status:   "assigned",
+			customer: true,
+			steps: []step{
+				{status("d1", "p1", "picked_up"), &entities.StreamEvent{Type: "status", DeliveryID: "d1", Status: "picked_up"}},
+				{location("p1"), &entities.StreamEvent{Type: "location", DeliveryID: "d1", Location: &entities.StreamLocation{Latitude: roundedLat, Longitude: roundedLng}}},
+				{status("d1", "p1", "in_transit"), &entities.StreamEvent{Type: "status", DeliveryID: "d1", Status: "in_transit"}},
+				{location("p1"), &entities.StreamEvent{Type: "location", DeliveryID: "d1", Location: &entities.StreamLocation{Latitude: roundedLat, Longitude: roundedLng}}},
+			},
+		},
+		{
+			name:     "customers get no location once delivered",
+			status:   "in_transit",
+			customer: true,
+			steps: []step{
+				{status("d1", "p1", "delivered"), &entities.StreamEvent{Type: "status", DeliveryID: "d1", Status: "delivered"}},
+				{location("p1"), nil},
+			},
+		},
+		{
+			name:   "no locations after the order is unassigned",
+			status: "assigned",
+			steps: []step{
+				{status("d1", "p1", "unassigned"), &entities.StreamEvent{Type: "status", DeliveryID: "d1", PartnerID: "p1", Status: "unassigned"}},
+				{location("p1"), nil},
+			},
+		},
+		{
+			name:   "locations follow a reassignment",
+			status: "assigned",
+			steps: []step{
+				{status("d1", "p2", "assigned"), &entities.StreamEvent{Type: "status", DeliveryID: "d1", PartnerID: "p2", Status: "assigned"}},
+				{location("p1"), nil},
+				{location("p2"), &entities.StreamEvent{Type: "location", DeliveryID: "d1", PartnerID: "p2", Location: &entities.StreamLocation{Latitude: lat, Longitude: lng}}},
+			},
+		},
+		{
+			name:   "other deliveries and partners are skipped",
+			status: "picked_up",
+			steps: []step{
+				{status("d2", "p1", "delivered"), nil},
+				{location("p3"), nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := &entities.Delivery{DeliveryID: "d1", PartnerID: "p1", Status: tt.status}
+			filter := deliveryStreamFilter(delivery, tt.customer)
+
+			for i, s := range tt.steps {
+				got := filter(s.event)
+				if s.want == nil {
+					if got != nil {
+						t.Fatalf("step %d: got %+v, want it skipped", i, *got)
+					}
+					continue
+				}
+				if got == nil {
+					t.Fatalf("step %d: skipped, want %+v", i, *s.want)
+				}
+				if got.Type != s.want.Type || got.DeliveryID != s.want.DeliveryID ||
+					got.PartnerID != s.want.PartnerID || got.Status != s.want.Status {
+					t.Fatalf("step %d: got %+v, want %+v", i, *got, *s.want)
+				}
+				if (got.Location == nil) != (s.want.Location == nil) {
+					t.Fatalf("step %d: location = %v, want %v", i, got.Location, s.want.Location)
+				}
+				if got.Location != nil &&
+					(got.Location.Latitude != s.want.Location.Latitude || got.Location.Longitude != s.want.Location.Longitude) {
+					t.Fatalf("step %d: location = %v,%v, want %v,%v", i,
+						got.Location.Latitude, got.Location.Longitude, s.want.Location.Latitude, s.want.Location.Longitude)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/utils"
+	"math"
+	"strings"
+	"time"
+)
+
+type TrackingUseCase struct {
+	deliveryRepo repositories.DeliveryRepository
+	partnerRepo  repositories.DeliveryPartnerRepository
+	baseURL      string
+	linkTTL      time.Duration
+}
+
+func NewTrackingUseCase(
+	deliveryRepo repositories.DeliveryRepository,
+	partnerRepo repositories.DeliveryPartnerRepository,
+	baseURL string,
+	linkTTL time.Duration,
+) *TrackingUseCase {
+	return &TrackingUseCase{
+		deliveryRepo: deliveryRepo,
+		partnerRepo:  partnerRepo,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		linkTTL:      linkTTL,
+	}
+}
+
+// IssueTrackingLink returns the delivery's tracking link, generating a new
+// token when there is none or the current one has expired.
+func (uc *TrackingUseCase) IssueTrackingLink(delivery *entities.Delivery) (*entities.TrackingLinkResponse, error) {
+	link, err := uc.PrepareTrackingLink(delivery)
+	if err != nil || link.Token == delivery.TrackingToken {
+		return link, err
+	}
+
+	if err := uc.deliveryRepo.SetTrackingToken(delivery.DeliveryID, link.Token, link.ExpiresAt); err != nil {
+		return &entities.TrackingLinkResponse{
+			Success: false,
+			Error:   "Failed to save tracking token",
+		}, err
+	}
+
+	delivery.TrackingToken = link.Token
+	delivery.TrackingExpiresAt = &link.ExpiresAt
+	return link, nil
+}
+
+// PrepareTrackingLink returns the delivery's current tracking link, or a new
+// one without saving it, for callers that store the token in their own write
+func (uc *TrackingUseCase) PrepareTrackingLink(delivery *entities.Delivery) (*entities.TrackingLinkResponse, error) {
+	now := time.Now()
+	if delivery.TrackingToken != "" && delivery.TrackingExpiresAt != nil && now.Before(*delivery.TrackingExpiresAt) {
+		return uc.linkResponse(delivery.TrackingToken, *delivery.TrackingExpiresAt), nil
+	}
+
+	token, err := utils.RandomHex(24)
+	if err != nil {
+		return &entities.TrackingLinkResponse{
+			Success: false,
+			Error:   "Failed to generate tracking token",
+		}, err
+	}
+
+	// Scheduled orders must stay trackable until their window has passed
+	expiresAt := now.Add(uc.linkTTL)
+	if delivery.WindowEnd != nil && delivery.WindowEnd.Add(uc.linkTTL).After(expiresAt) {
+		expiresAt = delivery.WindowEnd.Add(uc.linkTTL)
+	}
+
+	return uc.linkResponse(token, expiresAt), nil
+}
+
+func (uc *TrackingUseCase) IssueTrackingLinkByID(deliveryID string) (*entities.TrackingLinkResponse, error) {
+	delivery, err := uc.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		return &entities.TrackingLinkResponse{
+			Success: false,
+			Error:   "Order not found",
+		}, err
+	}
+
+	return uc.IssueTrackingLink(delivery)
+}
+
+func (uc *TrackingUseCase) linkResponse(token string, expiresAt time.Time) *entities.TrackingLinkResponse {
+	return &entities.TrackingLinkResponse{
+		Success:   true,
+		Token:     token,
+		URL:       uc.baseURL + "/" + token,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// ResolveToken returns the delivery for a valid, unexpired tracking token, or nil
+func (uc *TrackingUseCase) ResolveToken(token string) (*entities.Delivery, error) {
+	delivery, err := uc.deliveryRepo.GetByTrackingToken(token)
+	if err != nil || delivery == nil {
+		return nil, err
+	}
+
+	if delivery.TrackingExpiresAt == nil || time.Now().After(*delivery.TrackingExpiresAt) {
+		return nil, nil
+	}
+
+	return delivery, nil
+}
+
+func (uc *TrackingUseCase) GetTracking(token string) (*entities.TrackingResponse, error) {
+	delivery, err := uc.ResolveToken(token)
+	if err != nil {
+		return &entities.TrackingResponse{
+			Success: false,
+			Error:   "Failed to load tracking",
+		}, err
+	}
+
+	if delivery == nil {
+		return &entities.TrackingResponse{
+			Success: false,
+			Error:   "Tracking link is invalid or has expired",
+		}, nil
+	}
+
+	response := &entities.TrackingResponse{
+		Success:     true,
+		OrderID:     delivery.OrderID,
+		Status:      delivery.Status,
+		WindowStart: delivery.WindowStart,
+		WindowEnd:   delivery.WindowEnd,
+		DeliveredAt: delivery.DeliveredAt,
+	}
+
+	if delivery.Status == "delivered" || delivery.Status == "cancelled" || delivery.PartnerID == "" {
+		return response, nil
+	}
+
+	response.ETA = delivery.ETA
+
+	partner, err := uc.partnerRepo.FindByID(delivery.PartnerID)
+	if err != nil {
+		return response, nil
+	}
+
+	response.Partner = &entities.TrackingPartner{
+		FirstName:   firstName(partner.Name),
+		VehicleType: partner.VehicleType,
+	}
+
+	// Location is only shared while the parcel is on its way to the customer
+	if (delivery.Status == "picked_up" || delivery.Status == "in_transit") &&
+		(partner.CurrentLatitude != 0 || partner.CurrentLongitude != 0) {
+		response.Location = &entities.TrackingLocation{
+			Latitude:  approximateCoordinate(partner.CurrentLatitude),
+			Longitude: approximateCoordinate(partner.CurrentLongitude),
+			UpdatedAt: partner.LastLocationAt,
+		}
+	}
+
+	return response, nil
+}
+
+func firstName(name string) string {
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// approximateCoordinate rounds to 3 decimals, roughly 100 m
+func approximateCoordinate(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/domain/services"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Watchdog policies for orders not picked up yet. Orders already picked up
+// are always only alerted on, since the parcel is with the partner.
+const (
+	WatchdogPolicyReassign = "reassign" // release and dispatch to someone else right away
+	WatchdogPolicyRelease  = "release"  // release to the pool for the next dispatch pass
+	WatchdogPolicyAlert    = "alert"    // leave the order and alert support
+)
+
+// validWatchdogPolicy reports whether the policy is one of the above
+func validWatchdogPolicy(policy string) bool {
+	switch policy {
+	case WatchdogPolicyReassign, WatchdogPolicyRelease, WatchdogPolicyAlert:
+		return true
+	}
+	return false
+}
+
+type WatchdogConfig struct {
+	Policy string
+	// A partner who hasn't sent a location for this long counts as offline
+	OfflineAfter time.Duration
+	// Assigned orders not picked up within this are taken back
+	AssignedTimeout time.Duration
+	// Picked up orders not delivered within this are escalated
+	InTransitTimeout time.Duration
+}
+
+// WatchdogUseCase finds orders stranded with partners who went offline or
+// stopped making progress and hands them over according to policy
+type WatchdogUseCase struct {
+	deliveryRepo repositories.DeliveryRepository
+	partnerRepo  repositories.DeliveryPartnerRepository
+	dispatcher   Dispatcher
+	notifier     services.Notifier
+	config       WatchdogConfig
+}
+
+func NewWatchdogUseCase(
+	deliveryRepo repositories.DeliveryRepository,
+	partnerRepo repositories.DeliveryPartnerRepository,
+	dispatcher Dispatcher,
+	notifier services.Notifier,
+	config WatchdogConfig,
+) *WatchdogUseCase {
+	if !validWatchdogPolicy(config.Policy) {
+		log.Printf("⚠️  Unknown watchdog policy %q; using %q", config.Policy, WatchdogPolicyReassign)
+		config.Policy = WatchdogPolicyReassign
+	}
+	return &WatchdogUseCase{
+		deliveryRepo: deliveryRepo,
+		partnerRepo:  partnerRepo,
+		dispatcher:   dispatcher,
+		notifier:     notifier,
+		config:       config,
+	}
+}
+
+// Run checks for stranded orders until the context is cancelled
+func (uc *WatchdogUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			response, err := uc.Check()
+			if err != nil {
+				log.Printf("❌ Watchdog check failed: %v", err)
+				continue
+			}
+			if response.Count > 0 {
+				log.Printf("🐕 Watchdog acted on %d orders", response.Count)
+			}
+		}
+	}
+}
+
+func (uc *WatchdogUseCase) Check() (*entities.WatchdogRunResponse, error) {
+	deliveries, err := uc.deliveryRepo.GetInProgressDeliveries()
+	if err != nil {
+		return &entities.WatchdogRunResponse{
+			Success: false,
+			Error:   "Failed to load deliveries",
+		}, err
+	}
+
+	now := time.Now()
+	partners := make(map[string]*entities.DeliveryPartner)
+	unknown := make(map[string]bool) // lookups that failed this run
+	actions := make([]entities.WatchdogAction, 0)
+
+	for i := range deliveries {
+		d := &deliveries[i]
+		if d.PartnerID == "" || unknown[d.PartnerID] {
+			continue
+		}
+
+		// A nil partner means the account is gone; any other error says
+		// nothing about the partner, so their orders are left alone
+		partner, ok := partners[d.PartnerID]
+		if !ok {
+			found, err := uc.partnerRepo.FindByID(d.PartnerID)
+			if err != nil && !errors.Is(err, repositories.ErrPartnerNotFound) {
+				log.Printf("❌ Watchdog failed to load partner %s: %v", d.PartnerID, err)
+				unknown[d.PartnerID] = true
+				continue
+			}
+			partner = found
+			partners[d.PartnerID] = partner
+		}
+
+		reason := uc.strandedReason(d, partner, now)
+		if reason == "" {
+			continue
+		}
+
+		var action entities.WatchdogAction
+		switch {
+		case d.Status == "pending" || d.Status == "assigned":
+			if uc.config.Policy == WatchdogPolicyAlert {
+				if alreadyFlagged(d) {
+					continue
+				}
+				action = uc.flag(d, reason)
+			} else {
+				action = uc.release(d, reason)
+			}
+		default:
+			if alreadyFlagged(d) {
+				continue
+			}
+			action = uc.flag(d, reason)
+		}
+		actions = append(actions, action)
+	}
+
+	return &entities.WatchdogRunResponse{
+		Success: true,
+		Policy:  uc.config.Policy,
+		Actions: actions,
+		Count:   len(actions),
+	}, nil
+}
+
+// strandedReason explains why the order needs attention, or returns "" if it doesn't
+func (uc *WatchdogUseCase) strandedReason(delivery *entities.Delivery, partner *entities.DeliveryPartner, now time.Time) string {
+	switch {
+	case partner == nil:
+		return "partner account not found"
+	case !partner.IsAvailable:
+		return "partner went unavailable"
+	case partner.LastLocationAt.IsZero():
+		return "partner has never shared a location"
+	case partner.LastLocationAt.Before(now.Add(-uc.config.OfflineAfter)):
+		return fmt.Sprintf("no location from the partner for %s", now.Sub(partner.LastLocationAt).Round(time.Minute))
+	}
+
+	switch delivery.Status {
+	case "assigned":
+		if !delivery.AssignedAt.IsZero() && delivery.AssignedAt.Before(now.Add(-uc.config.AssignedTimeout)) {
+			return fmt.Sprintf("not picked up %s after assignment", now.Sub(delivery.AssignedAt).Round(time.Minute))
+		}
+	case "picked_up", "in_transit":
+		if delivery.PickedUpAt != nil && delivery.PickedUpAt.Before(now.Add(-uc.config.InTransitTimeout)) {
+			return fmt.Sprintf("not delivered %s after pickup", now.Sub(*delivery.PickedUpAt).Round(time.Minute))
+		}
+	}
+	return ""
+}
+
+// release takes the order back from the partner and, under the reassign
+// policy, dispatches it again straight away
+func (uc *WatchdogUseCase) release(delivery *entities.Delivery, reason string) entities.WatchdogAction {
+	action := entities.WatchdogAction{
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+		PartnerID:  delivery.PartnerID,
+		Status:     delivery.Status,
+		Reason:     reason,
+	}
+
+	entry := &entities.TimelineEntry{
+		Type:              "released",
+		Status:            delivery.Status,
+		PreviousPartnerID: delivery.PartnerID,
+		Actor:             "watchdog",
+		Reason:            reason,
+	}
+	event := newDeliveryEvent(delivery, "unassigned", map[string]interface{}{
+		"reason": reason,
+		"actor":  "watchdog",
+	})
+
+	if err := uc.deliveryRepo.ReleaseOrder(delivery.DeliveryID, delivery.PartnerID, entry, event); err != nil {
+		action.Action = "failed"
+		action.Reason = err.Error()
+		return action
+	}
+	action.Action = "released"
+
+	if uc.config.Policy == WatchdogPolicyReassign {
+		decision, err := uc.dispatcher.Redispatch(delivery.DeliveryID, []string{delivery.PartnerID})
+		if err != nil {
+			log.Printf("❌ Failed to redispatch delivery %s: %v", delivery.DeliveryID, err)
+		} else if decision.Action == "assigned" {
+			action.Action = "reassigned"
+		} else if decision.Action == "offered" {
+			action.Action = "offered"
+		}
+	}
+
+	uc.notify(delivery, "watchdog.released", "medium",
+		fmt.Sprintf("Order %s was taken back from partner %s (%s) and %s", delivery.OrderID, delivery.PartnerID, reason, action.Action))
+	uc.send(&entities.Notification{
+		Type:       "order.released",
+		Severity:   "medium",
+		Audience:   entities.AudiencePartner,
+		Title:      fmt.Sprintf("Order %s was taken back", delivery.OrderID),
+		Message:    fmt.Sprintf("Order %s is no longer yours (%s). You don't need to pick it up.", delivery.OrderID, reason),
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+		PartnerID:  delivery.PartnerID,
+		CreatedAt:  time.Now(),
+	})
+	uc.send(&entities.Notification{
+		Type:       "order.partner_changed",
+		Severity:   "low",
+		Audience:   entities.AudienceCustomer,
+		Title:      "We're finding you a new delivery partner",
+		Message:    fmt.Sprintf("Your delivery partner couldn't continue with order %s. We're assigning a new one.", delivery.OrderID),
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+		CustomerID: delivery.CustomerID,
+		CreatedAt:  time.Now(),
+	})
+	return action
+}
+
+// flag records that the order is stuck and alerts support, once per status
+func (uc *WatchdogUseCase) flag(delivery *entities.Delivery, reason string) entities.WatchdogAction {
+	action := entities.WatchdogAction{
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+		PartnerID:  delivery.PartnerID,
+		Status:     delivery.Status,
+		Action:     "alerted",
+		Reason:     reason,
+	}
+
+	entry := &entities.TimelineEntry{
+		Type:      "stalled",
+		Status:    delivery.Status,
+		PartnerID: delivery.PartnerID,
+		Actor:     "watchdog",
+		Reason:    reason,
+	}
+	if err := uc.deliveryRepo.AddTimelineEntry(delivery.DeliveryID, entry); err != nil {
+		action.Action = "failed"
+		action.Reason = err.Error()
+		return action
+	}
+
+	uc.notify(delivery, "watchdog.stalled", "high",
+		fmt.Sprintf("Order %s (%s) is stuck with partner %s: %s", delivery.OrderID, delivery.Status, delivery.PartnerID, reason))
+	return action
+}
+
+// notify alerts support about the order
+func (uc *WatchdogUseCase) notify(delivery *entities.Delivery, notificationType, severity, message string) {
+	uc.send(&entities.Notification{
+		Type:       notificationType,
+		Severity:   severity,
+		Audience:   entities.AudienceSupport,
+		Title:      fmt.Sprintf("Order %s needs attention", delivery.OrderID),
+		Message:    message,
+		DeliveryID: delivery.DeliveryID,
+		OrderID:    delivery.OrderID,
+		PartnerID:  delivery.PartnerID,
+		CreatedAt:  time.Now(),
+	})
+}
+
+func (uc *WatchdogUseCase) send(notification *entities.Notification) {
+	go func() {
+		if err := uc.notifier.Notify(notification); err != nil {
+			log.Printf("❌ Failed to send %s notification for delivery %s: %v", uc.notifier.Name(), notification.DeliveryID, err)
+		}
+	}()
+}
+
+// alreadyFlagged reports whether the watchdog already flagged the order with
+// its current partner and status
+func alreadyFlagged(delivery *entities.Delivery) bool {
+	for _, entry := range delivery.Timeline {
+		if entry.Type == "stalled" && entry.Status == delivery.Status && entry.PartnerID == delivery.PartnerID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/utils"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	webhookBatchSize = 50
+	webhookLease     = 2 * time.Minute
+	retryBaseBackoff = 30 * time.Second
+	retryMaxBackoff  = 6 * time.Hour
+)
+
+type WebhookUseCase struct {
+	webhookRepo repositories.WebhookRepository
+	httpClient  *http.Client
+	maxAttempts int
+}
+
+func NewWebhookUseCase(webhookRepo repositories.WebhookRepository, maxAttempts int) *WebhookUseCase {
+	return &WebhookUseCase{
+		webhookRepo: webhookRepo,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (uc *WebhookUseCase) CreateSubscription(req *entities.CreateWebhookSubscriptionRequest) (*entities.CreateWebhookSubscriptionResponse, error) {
+	secret := req.Secret
+	if secret == "" {
+		generated, err := utils.RandomHex(32)
+		if err != nil {
+			return &entities.CreateWebhookSubscriptionResponse{
+				Success: false,
+				Error:   "Failed to generate secret",
+			}, err
+		}
+		secret = generated
+	}
+
+	subscription := &entities.WebhookSubscription{
+		Name:     req.Name,
+		URL:      req.URL,
+		Secret:   secret,
+		Events:   req.Events,
+		IsActive: true,
+	}
+
+	if err := uc.webhookRepo.CreateSubscription(subscription); err != nil {
+		return &entities.CreateWebhookSubscriptionResponse{
+			Success: false,
+			Error:   "Failed to create subscription",
+		}, err
+	}
+
+	return &entities.CreateWebhookSubscriptionResponse{
+		Success:      true,
+		Subscription: subscription,
+		Secret:       secret,
+	}, nil
+}
+
+func (uc *WebhookUseCase) GetSubscriptions() (*entities.GetWebhookSubscriptionsResponse, error) {
+	subscriptions, err := uc.webhookRepo.GetSubscriptions(false)
+	if err != nil {
+		return &entities.GetWebhookSubscriptionsResponse{
+			Success: false,
+		}, err
+	}
+
+	if subscriptions == nil {
+		subscriptions = []entities.WebhookSubscription{}
+	}
+
+	return &entities.GetWebhookSubscriptionsResponse{
+		Success:       true,
+		Subscriptions: subscriptions,
+		Count:         len(subscriptions),
+	}, nil
+}
+
+func (uc *WebhookUseCase) DeleteSubscription(subscriptionID string) (*entities.ResponseMessage, error) {
+	if err := uc.webhookRepo.DeleteSubscription(subscriptionID); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to delete subscription",
+		}, err
+	}
+
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: "Subscription deleted",
+	}, nil
+}
+
+// Publish queues the event for every active subscriber whose filter matches.
+// Delivery happens asynchronously in Run.
+func (uc *WebhookUseCase) Publish(event *entities.DeliveryEvent) error {
+	subscriptions, err := uc.webhookRepo.GetSubscriptions(true)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	messages := make([]entities.WebhookMessage, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		if !subscribedTo(s.Events, event.Type) {
+			continue
+		}
+		messages = append(messages, entities.WebhookMessage{
+			SubscriptionID: s.SubscriptionID,
+			URL:            s.URL,
+			Event:          *event,
+			Status:         "pending",
+			NextAttemptAt:  now,
+		})
+	}
+
+	return uc.webhookRepo.EnqueueMessages(messages)
+}
+
+func subscribedTo(filter []string, eventType string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, e := range filter {
+		if e == eventType || e == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Run sends due webhook messages until the context is cancelled
+func (uc *WebhookUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.ProcessDue(); err != nil {
+				log.Printf("❌ Webhook dispatch failed: %v", err)
+			}
+		}
+	}
+}
+
+func (uc *WebhookUseCase) ProcessDue() error {
+	messages, err := uc.webhookRepo.ClaimDueMessages(webhookBatchSize, webhookLease)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	// Secrets are looked up per batch so rotated or deleted subscriptions apply immediately
+	subscriptions, err := uc.webhookRepo.GetSubscriptions(false)
+	if err != nil {
+		return err
+	}
+	secrets := make(map[string]string, len(subscriptions))
+	for _, s := range subscriptions {
+		secrets[s.SubscriptionID] = s.Secret
+	}
+
+	for _, m := range messages {
+		uc.send(&m, secrets)
+	}
+	return nil
+}
+
+func (uc *WebhookUseCase) send(message *entities.WebhookMessage, secrets map[string]string) {
+	attempts := message.Attempts + 1
+
+	secret, ok := secrets[message.SubscriptionID]
+	if !ok {
+		uc.webhookRepo.MarkDead(message.MessageID, attempts, 0, "subscription no longer exists")
+		return
+	}
+
+	statusCode, err := uc.post(message, secret)
+	if err == nil {
+		if err := uc.webhookRepo.MarkDelivered(message.MessageID, attempts, statusCode); err != nil {
+			log.Printf("❌ Failed to mark webhook %s delivered: %v", message.MessageID, err)
+		}
+		return
+	}
+
+	if attempts >= uc.maxAttempts {
+		log.Printf("⚠️  Webhook %s moved to dead letters after %d attempts: %v", message.MessageID, attempts, err)
+		uc.webhookRepo.MarkDead(message.MessageID, attempts, statusCode, err.Error())
+		return
+	}
+
+	uc.webhookRepo.MarkRetry(message.MessageID, attempts, time.Now().Add(retryBackoff(attempts)), statusCode, err.Error())
+}
+
+func (uc *WebhookUseCase) post(message *entities.WebhookMessage, secret string) (int, error) {
+	body, err := json.Marshal(message.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, message.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Espaze-Event", message.Event.Type)
+	req.Header.Set("X-Espaze-Event-Id", message.Event.EventID)
+	req.Header.Set("X-Espaze-Timestamp", timestamp)
+	req.Header.Set("X-Espaze-Signature", "sha256="+SignWebhookPayload(secret, timestamp, body))
+
+	resp, err := uc.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+// Subscribers recompute it with their secret to verify X-Espaze-Signature.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryBackoff doubles the delay after every failed attempt
+func retryBackoff(attempts int) time.Duration {
+	delay := retryBaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= retryMaxBackoff {
+			return retryMaxBackoff
+		}
+	}
+	return delay
+}
+
+func (uc *WebhookUseCase) GetDeadLetters(req *entities.GetDeadLettersRequest) (*entities.GetDeadLettersResponse, error) {
+	messages, total, err := uc.webhookRepo.GetDeadLetters(req.Limit, req.Offset)
+	if err != nil {
+		return &entities.GetDeadLettersResponse{
+			Success: false,
+		}, err
+	}
+
+	if messages == nil {
+		messages = []entities.WebhookMessage{}
+	}
+
+	return &entities.GetDeadLettersResponse{
+		Success:     true,
+		Messages:    messages,
+		Total:       total,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+		HasNext:     (req.Offset + req.Limit) < total,
+		HasPrevious: req.Offset > 0,
+	}, nil
+}
+
+func (uc *WebhookUseCase) Redeliver(messageID string) (*entities.ResponseMessage, error) {
+	if err := uc.webhookRepo.Redeliver(messageID); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to schedule redelivery",
+		}, err
+	}
+
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: "Redelivery scheduled",
+	}, nil
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"deliveryAppBackend/domain/entities"
+	"deliveryAppBackend/domain/repositories"
+	"deliveryAppBackend/utils"
+	"errors"
+	"fmt"
+	"math"
+)
+
+type ZoneUseCase struct {
+	zoneRepo    repositories.ZoneRepository
+	partnerRepo repositories.DeliveryPartnerRepository
+}
+
+func NewZoneUseCase(zoneRepo repositories.ZoneRepository, partnerRepo repositories.DeliveryPartnerRepository) *ZoneUseCase {
+	return &ZoneUseCase{
+		zoneRepo:    zoneRepo,
+		partnerRepo: partnerRepo,
+	}
+}
+
+func (uc *ZoneUseCase) CreateZone(req *entities.ZoneRequest) (*entities.ZoneResponse, error) {
+	if problem := validatePolygon(&req.Area); problem != "" {
+		return &entities.ZoneResponse{
+			Success: false,
+			Message: problem,
+		}, nil
+	}
+
+	zone := &entities.Zone{
+		Name:         req.Name,
+		WarehouseIDs: req.WarehouseIDs,
+		Area:         req.Area,
+		IsActive:     req.IsActive == nil || *req.IsActive,
+	}
+	if err := uc.zoneRepo.Create(zone); err != nil {
+		return &entities.ZoneResponse{
+			Success: false,
+			Error:   "Failed to create zone",
+		}, err
+	}
+
+	return &entities.ZoneResponse{
+		Success: true,
+		Message: "Zone created",
+		Zone:    zone,
+	}, nil
+}
+
+func (uc *ZoneUseCase) UpdateZone(zoneID string, req *entities.ZoneRequest) (*entities.ZoneResponse, error) {
+	zone, err := uc.zoneRepo.GetByID(zoneID)
+	if err != nil {
+		return &entities.ZoneResponse{
+			Success: false,
+			Error:   "Zone not found",
+		}, err
+	}
+
+	if problem := validatePolygon(&req.Area); problem != "" {
+		return &entities.ZoneResponse{
+			Success: false,
+			Message: problem,
+		}, nil
+	}
+
+	zone.Name = req.Name
+	zone.WarehouseIDs = req.WarehouseIDs
+	zone.Area = req.Area
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+	if err := uc.zoneRepo.Update(zone); err != nil {
+		return &entities.ZoneResponse{
+			Success: false,
+			Error:   "Failed to update zone",
+		}, err
+	}
+
+	return &entities.ZoneResponse{
+		Success: true,
+		Message: "Zone updated",
+		Zone:    zone,
+	}, nil
+}
+
+func (uc *ZoneUseCase) GetZone(zoneID string) (*entities.ZoneResponse, error) {
+	zone, err := uc.zoneRepo.GetByID(zoneID)
+	if err != nil {
+		return &entities.ZoneResponse{
+			Success: false,
+			Error:   "Zone not found",
+		}, err
+	}
+
+	return &entities.ZoneResponse{
+		Success: true,
+		Zone:    zone,
+	}, nil
+}
+
+func (uc *ZoneUseCase) GetZones(req *entities.GetZonesRequest) (*entities.GetZonesResponse, error) {
+	zones, err := uc.zoneRepo.GetAll(req.WarehouseID, req.IncludeInactive)
+	if err != nil {
+		return &entities.GetZonesResponse{
+			Success: false,
+		}, err
+	}
+
+	return &entities.GetZonesResponse{
+		Success: true,
+		Zones:   zones,
+		Count:   len(zones),
+	}, nil
+}
+
+func (uc *ZoneUseCase) SetPartnerHomeZone(partnerID string, req *entities.SetHomeZoneRequest) (*entities.ResponseMessage, error) {
+	if _, err := uc.partnerRepo.FindByID(partnerID); err != nil {
+		if errors.Is(err, repositories.ErrPartnerNotFound) {
+			return &entities.ResponseMessage{
+				Success: false,
+				Message: "Partner not found",
+			}, nil
+		}
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to load partner",
+		}, err
+	}
+
+	if req.ZoneID != "" {
+		zone, err := uc.zoneRepo.GetByID(req.ZoneID)
+		if errors.Is(err, repositories.ErrZoneNotFound) {
+			return &entities.ResponseMessage{
+				Success: false,
+				Message: "Zone not found",
+			}, nil
+		}
+		if err != nil {
+			return &entities.ResponseMessage{
+				Success: false,
+				Error:   "Failed to load zone",
+			}, err
+		}
+		if !zone.IsActive {
+			return &entities.ResponseMessage{
+				Success: false,
+				Message: "Zone is not active",
+			}, nil
+		}
+	}
+
+	if err := uc.partnerRepo.UpdateProfile(partnerID, map[string]interface{}{"homeZoneId": req.ZoneID}); err != nil {
+		return &entities.ResponseMessage{
+			Success: false,
+			Error:   "Failed to update home zone",
+		}, err
+	}
+
+	message := "Home zone updated"
+	if req.ZoneID == "" {
+		message = "Home zone cleared"
+	}
+	return &entities.ResponseMessage{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+// CheckServiceability reports the active zones that cover the coordinate
+func (uc *ZoneUseCase) CheckServiceability(req *entities.ServiceabilityRequest) (*entities.ServiceabilityResponse, error) {
+	zones, err := uc.zoneRepo.FindContaining(*entities.NewGeoPoint(req.Latitude, req.Longitude))
+	if err != nil {
+		return &entities.ServiceabilityResponse{
+			Success: false,
+		}, err
+	}
+
+	serviceable := make([]entities.ServiceableZone, 0, len(zones))
+	for _, z := range zones {
+		serviceable = append(serviceable, entities.ServiceableZone{
+			ID:           z.ZoneID,
+			Name:         z.Name,
+			WarehouseIDs: z.WarehouseIDs,
+		})
+	}
+
+	return &entities.ServiceabilityResponse{
+		Success:     true,
+		Serviceable: len(serviceable) > 0,
+		Zones:       serviceable,
+	}, nil
+}
+
+// validatePolygon checks what MongoDB would otherwise reject with a less
+// helpful error, and returns "" for a usable polygon
+func validatePolygon(polygon *entities.GeoPolygon) string {
+	if polygon.Type == "" {
+		polygon.Type = "Polygon"
+	}
+	if polygon.Type != "Polygon" {
+		return "area must be a GeoJSON Polygon"
+	}
+	if len(polygon.Coordinates) == 0 {
+		return "area needs at least one ring"
+	}
+
+	for i, ring := range polygon.Coordinates {
+		if len(ring) < 4 {
+			return fmt.Sprintf("ring %d needs at least 4 positions", i)
+		}
+		for _, position := range ring {
+			if len(position) != 2 {
+				return fmt.Sprintf("ring %d has a position that is not [longitude, latitude]", i)
+			}
+			if math.Abs(position[0]) > 180 || math.Abs(position[1]) > 90 {
+				return fmt.Sprintf("ring %d has a position out of range", i)
+			}
+		}
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			return fmt.Sprintf("ring %d must end where it starts", i)
+		}
+	}
+	return ""
+}
+
+// zoneDistanceKm is 0 inside the zone and the distance to its edge outside
+func zoneDistanceKm(zone *entities.Zone, latitude, longitude float64) float64 {
+	rings := zone.Area.Coordinates
+	if len(rings) == 0 {
+		return math.Inf(1)
+	}
+
+	inside := utils.PointInRing(latitude, longitude, rings[0])
+	for _, hole := range rings[1:] {
+		if utils.PointInRing(latitude, longitude, hole) {
+			inside = false
+		}
+	}
+	if inside {
+		return 0
+	}
+
+	nearest := math.Inf(1)
+	for _, ring := range rings {
+		nearest = math.Min(nearest, utils.DistanceToRingKm(latitude, longitude, ring))
+	}
+	return nearest
+}
+
+// servesZones reports whether the partner belongs to one of the zones, or is
+// currently in or within nearKm of one
+func servesZones(partner *entities.DeliveryPartner, zones []entities.Zone, nearKm float64) bool {
+	for i := range zones {
+		if partner.HomeZoneID == zones[i].ZoneID {
+			return true
+		}
+		if zoneDistanceKm(&zones[i], partner.CurrentLatitude, partner.CurrentLongitude) <= nearKm {
+			return true
+		}
+	}
+	return false
+}
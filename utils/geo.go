@@ -0,0 +1,56 @@
+package utils
+
+import "math"
+
+const earthRadiusKm = 6371.0
+
+// HaversineKm returns the great-circle distance between two coordinates in km
+func HaversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// PointInRing reports whether the point lies inside a closed ring of
+// [longitude, latitude] positions, using ray casting
+func PointInRing(lat, lon float64, ring [][]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > lat) != (yj > lat) && lon < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceToRingKm returns the distance from the point to the nearest edge of
+// the ring. Accurate enough for the few kilometres around a zone.
+func DistanceToRingKm(lat, lon float64, ring [][]float64) float64 {
+	// Project onto a plane around the point, in km
+	kmPerDegLat := earthRadiusKm * math.Pi / 180
+	kmPerDegLon := kmPerDegLat * math.Cos(lat*math.Pi/180)
+	project := func(p []float64) (float64, float64) {
+		return (p[0] - lon) * kmPerDegLon, (p[1] - lat) * kmPerDegLat
+	}
+
+	nearest := math.Inf(1)
+	for i := 0; i+1 < len(ring); i++ {
+		ax, ay := project(ring[i])
+		bx, by := project(ring[i+1])
+
+		// Closest point on segment AB to the origin
+		dx, dy := bx-ax, by-ay
+		t := 0.0
+		if lengthSq := dx*dx + dy*dy; lengthSq > 0 {
+			t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSq))
+		}
+		nearest = math.Min(nearest, math.Hypot(ax+t*dx, ay+t*dy))
+	}
+	return nearest
+}
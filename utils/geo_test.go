@@ -0,0 +1,33 @@
+package utils
+
+import "testing"
+
+func TestPointInRing(t *testing.T) {
+	// An L shape, [longitude, latitude], closed
+	ring := [][]float64{
+		{77.0, 12.0}, {77.2, 12.0}, {77.2, 12.1}, {77.1, 12.1},
+		{77.1, 12.2}, {77.0, 12.2}, {77.0, 12.0},
+	}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     bool
+	}{
+		{"inside the foot", 12.05, 77.15, true},
+		{"inside the upright", 12.15, 77.05, true},
+		{"in the notch", 12.15, 77.15, false},
+		{"west of the ring", 12.05, 76.9, false},
+		{"north of the ring", 12.3, 77.05, false},
+		// Latitude and longitude must not be swapped
+		{"swapped coordinates", 77.05, 12.05, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PointInRing(tt.lat, tt.lon, ring); got != tt.want {
+				t.Errorf("PointInRing(%v, %v) = %v, want %v", tt.lat, tt.lon, got, tt.want)
+			}
+		})
+	}
+}